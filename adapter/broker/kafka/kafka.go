@@ -34,8 +34,53 @@ type Kafka struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	clients      []sarama.Client
+	client       sarama.Client // producer client
 	syncProducer sarama.SyncProducer
 	opt          b.Options
+
+	// consumeCtx is canceled to stop consuming on drain
+	consumeCtx  context.Context
+	stopConsume context.CancelFunc
+	inflight    inflight
+}
+
+// inflight tracks the running handlers, no handler is acquired after drain starts.
+type inflight struct {
+	mu       sync.Mutex
+	draining bool
+	wg       sync.WaitGroup
+}
+
+func (f *inflight) acquire() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.draining {
+		return false
+	}
+	f.wg.Add(1)
+	return true
+}
+
+func (f *inflight) release() {
+	f.wg.Done()
+}
+
+func (f *inflight) drain(ctx context.Context) error {
+	f.mu.Lock()
+	f.draining = true
+	f.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func New(opts ...b.Option) b.Broker {
@@ -49,10 +94,13 @@ func New(opts ...b.Option) b.Broker {
 	}
 
 	ctx, cancel := context.WithCancel(opt.Context)
+	consumeCtx, stopConsume := context.WithCancel(ctx)
 	k := &Kafka{
-		opt:    opt,
-		ctx:    ctx,
-		cancel: cancel,
+		opt:         opt,
+		ctx:         ctx,
+		cancel:      cancel,
+		consumeCtx:  consumeCtx,
+		stopConsume: stopConsume,
 	}
 
 	broker := b.Broker(k)
@@ -130,6 +178,7 @@ func (broker *Kafka) Connect() error {
 	producer = otelsarama.WrapSyncProducer(cfg, producer)
 
 	broker.mutex.Lock()
+	broker.client = client
 	broker.syncProducer = producer
 	broker.connected = true
 	defer broker.mutex.Unlock()
@@ -164,6 +213,40 @@ func (broker *Kafka) Disconnect() error {
 	return nil
 }
 
+// Drain stops consuming and waits for the in-flight handlers,
+// the messages not handled are not marked and consumed again by the group.
+func (broker *Kafka) Drain(ctx context.Context) error {
+	broker.stopConsume()
+	if err := broker.inflight.drain(ctx); err != nil {
+		logger.Warnf("broker [%s] drain error: %v", broker.String(), err)
+		return err
+	}
+
+	logger.Infof("broker [%s] drained", broker.String())
+	return nil
+}
+
+// Health checks the connection to the kafka cluster.
+func (broker *Kafka) Health(_ context.Context) error {
+	if broker.isClosed() {
+		return errors.New("broker: kafka closed")
+	}
+
+	broker.mutex.Lock()
+	client := broker.client
+	broker.mutex.Unlock()
+	if client == nil {
+		return errors.New("broker: kafka not connected")
+	}
+	if client.Closed() {
+		return errors.New("broker: kafka client closed")
+	}
+	if _, err := client.RefreshController(); err != nil {
+		return fmt.Errorf("broker: kafka error: %v", err)
+	}
+	return nil
+}
+
 func (broker *Kafka) Publish(ctx context.Context, topic string, msg *b.Message, opts ...b.PublishOption) error {
 	if broker.isClosed() {
 		return io.EOF
@@ -276,7 +359,8 @@ func (broker *Kafka) Subscribe(topic string, h b.Handler, opts ...b.SubscribeOpt
 		subOpt:        opt,
 		opt:           broker.opt,
 		consumerGroup: consumerGroup,
-		ctx:           broker.ctx,
+		ctx:           broker.consumeCtx,
+		inflight:      &broker.inflight,
 	}
 
 	topics := []string{topic}
@@ -285,7 +369,7 @@ func (broker *Kafka) Subscribe(topic string, h b.Handler, opts ...b.SubscribeOpt
 	go func() {
 		for {
 			select {
-			case <-broker.ctx.Done():
+			case <-broker.consumeCtx.Done():
 				goto close
 			case err, ok := <-consumerGroup.Errors():
 				if !ok {
@@ -294,7 +378,7 @@ func (broker *Kafka) Subscribe(topic string, h b.Handler, opts ...b.SubscribeOpt
 
 				logger.Errorf("consumer error: %v", err)
 			default:
-				err = consumerGroup.Consume(broker.ctx, topics, handler)
+				err = consumerGroup.Consume(broker.consumeCtx, topics, handler)
 				if errors.Is(err, sarama.ErrClosedConsumerGroup) || errors.Is(err, sarama.ErrClosedClient) {
 					goto close
 				} else if err != nil {
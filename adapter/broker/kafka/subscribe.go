@@ -71,6 +71,7 @@ type consumerGroupHandler struct {
 	handler       broker.Handler
 	subOpt        broker.SubscribeOptions
 	consumerGroup sarama.ConsumerGroup
+	inflight      *inflight
 }
 
 func (c *consumerGroupHandler) Setup(session sarama.ConsumerGroupSession) error {
@@ -92,7 +93,12 @@ func (c *consumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSession,
 			if !ok {
 				return nil
 			}
+			// draining, the message is consumed again after rebalance
+			if !c.inflight.acquire() {
+				return nil
+			}
 			ctx, err := c.Handler(msg, session, claim)
+			c.inflight.release()
 			if err != nil {
 				logger.WithContext(ctx).Errorf("broker [kafka]: subscriber , address: %s, topic: %s, error: %v", strings.Join(c.opt.Addrs, ","), msg.Topic, err)
 				continue
@@ -38,6 +38,16 @@ func NewWrapper(opts ...Option) broker.Wrapper {
 	}
 }
 
+// Health checks the health of the wrapped broker.
+func (w *wrapper) Health(ctx context.Context) error {
+	return broker.Health(ctx, w.Broker)
+}
+
+// Drain drains the wrapped broker.
+func (w *wrapper) Drain(ctx context.Context) error {
+	return broker.Drain(ctx, w.Broker)
+}
+
 // Publish a message to a topic
 func (w *wrapper) Publish(ctx context.Context, topic string, message *broker.Message, opts ...broker.PublishOption) error {
 	start := time.Now()
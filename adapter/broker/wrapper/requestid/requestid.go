@@ -0,0 +1,52 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/nextmicro/next/broker"
+	"github.com/nextmicro/next/pkg/requestid"
+)
+
+type wrapper struct {
+	broker.Broker
+}
+
+// NewWrapper returns a wrapper which carries the request id in the message header,
+// it should be the last wrapper, so the request id is in the context of the other wrappers on consume.
+func NewWrapper() broker.Wrapper {
+	return func(b broker.Broker) broker.Broker {
+		return &wrapper{Broker: b}
+	}
+}
+
+// Health checks the health of the wrapped broker.
+func (w *wrapper) Health(ctx context.Context) error {
+	return broker.Health(ctx, w.Broker)
+}
+
+// Drain drains the wrapped broker.
+func (w *wrapper) Drain(ctx context.Context) error {
+	return broker.Drain(ctx, w.Broker)
+}
+
+// Publish sets the request id of ctx into the message header.
+func (w *wrapper) Publish(ctx context.Context, topic string, message *broker.Message, opts ...broker.PublishOption) error {
+	if _, ok := requestid.FromContext(ctx); ok {
+		if message.Header == nil {
+			message.Header = make(map[string]string, 1)
+		}
+		requestid.Inject(ctx, message.Header)
+	}
+	return w.Broker.Publish(ctx, topic, message, opts...)
+}
+
+// Subscribe puts the request id of the message header into the handler context.
+func (w *wrapper) Subscribe(topic string, handler broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	h := func(ctx context.Context, event broker.Event) error {
+		if msg := event.Message(); msg != nil {
+			ctx = requestid.Extract(ctx, msg.Header)
+		}
+		return handler(ctx, event)
+	}
+	return w.Broker.Subscribe(topic, h, opts...)
+}
@@ -0,0 +1,41 @@
+package requestid_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nextmicro/next/adapter/broker/wrapper/requestid"
+	"github.com/nextmicro/next/broker"
+	rid "github.com/nextmicro/next/pkg/requestid"
+)
+
+func TestWrapper(t *testing.T) {
+	b := broker.NewMemoryBroker(
+		broker.Wrap(requestid.NewWrapper()),
+	)
+	if err := b.Connect(); err != nil {
+		t.Fatalf("Unexpected connect error %v", err)
+	}
+
+	var (
+		header string
+		id     string
+	)
+	sub, err := b.Subscribe("test", func(ctx context.Context, e broker.Event) error {
+		header = e.Message().Header[rid.HeaderKey]
+		id, _ = rid.FromContext(ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error subscribing %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	ctx := rid.NewContext(context.Background(), "abc")
+	if err := b.Publish(ctx, "test", &broker.Message{Body: []byte("hello")}); err != nil {
+		t.Fatalf("Unexpected error publishing %v", err)
+	}
+	if header != "abc" || id != "abc" {
+		t.Fatalf("expected request id abc, got header %q context %q", header, id)
+	}
+}
@@ -5,11 +5,12 @@ import (
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/nextmicro/logger"
+	"github.com/nextmicro/next/pkg/redact"
 )
 
 const defaultCallerSkipCount = 2
 
-// defaultLogger is an implementation of log.Logger interface.
+// defaultLogger is an implementation of log.Logger interface, the secrets marked by redact are masked.
 type defaultLogger struct {
 	base logger.Logger
 }
@@ -42,7 +43,9 @@ func (l *defaultLogger) Log(level log.Level, keyvals ...interface{}) error {
 	fields := make(map[string]interface{})
 	for i := 0; i < len(keyvals); i += 2 {
 		if keyvals[i] == log.DefaultMessageKey {
-			msg = fmt.Sprint(keyvals[i+1])
+			msg = redact.String(fmt.Sprint(keyvals[i+1]))
+		} else if v, ok := keyvals[i+1].(string); ok {
+			fields[fmt.Sprint(keyvals[i])] = redact.String(v)
 		} else {
 			fields[fmt.Sprint(keyvals[i])] = keyvals[i+1]
 		}
@@ -1,10 +1,14 @@
 package nacos
 
 import (
+	"fmt"
+
 	"github.com/nacos-group/nacos-sdk-go/v2/common/logger"
 	log "github.com/nextmicro/logger"
+	"github.com/nextmicro/next/pkg/redact"
 )
 
+// Nacos adapts the logger to the nacos logger, the secrets marked by redact are masked.
 type Nacos struct {
 	log.Logger
 }
@@ -18,33 +22,33 @@ func (l *Nacos) SetLogger() {
 }
 
 func (l *Nacos) Info(args ...interface{}) {
-	l.Logger.WithCallDepth(1).Info(args...)
+	l.Logger.WithCallDepth(1).Info(redact.String(fmt.Sprint(args...)))
 }
 
 func (l *Nacos) Warn(args ...interface{}) {
-	l.Logger.WithCallDepth(1).Warn(args...)
+	l.Logger.WithCallDepth(1).Warn(redact.String(fmt.Sprint(args...)))
 }
 
 func (l *Nacos) Error(args ...interface{}) {
-	l.Logger.WithCallDepth(1).Error(args...)
+	l.Logger.WithCallDepth(1).Error(redact.String(fmt.Sprint(args...)))
 }
 
 func (l *Nacos) Debug(args ...interface{}) {
-	l.Logger.WithCallDepth(1).Debug(args...)
+	l.Logger.WithCallDepth(1).Debug(redact.String(fmt.Sprint(args...)))
 }
 
-func (l *Nacos) Infof(fmt string, args ...interface{}) {
-	l.Logger.WithCallDepth(1).Infof(fmt, args...)
+func (l *Nacos) Infof(format string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Info(redact.String(fmt.Sprintf(format, args...)))
 }
 
-func (l *Nacos) Warnf(fmt string, args ...interface{}) {
-	l.Logger.WithCallDepth(1).Warnf(fmt, args...)
+func (l *Nacos) Warnf(format string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Warn(redact.String(fmt.Sprintf(format, args...)))
 }
 
-func (l *Nacos) Errorf(fmt string, args ...interface{}) {
-	l.Logger.WithCallDepth(1).Errorf(fmt, args...)
+func (l *Nacos) Errorf(format string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Error(redact.String(fmt.Sprintf(format, args...)))
 }
 
-func (l *Nacos) Debugf(fmt string, args ...interface{}) {
-	l.Logger.WithCallDepth(1).Debugf(fmt, args...)
+func (l *Nacos) Debugf(format string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Debug(redact.String(fmt.Sprintf(format, args...)))
 }
@@ -0,0 +1,219 @@
+package nacos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/nacos-group/nacos-sdk-go/v2/clients/naming_client"
+	"github.com/nacos-group/nacos-sdk-go/v2/model"
+	"github.com/nacos-group/nacos-sdk-go/v2/vo"
+)
+
+var (
+	_ registry.Registrar = (*Registry)(nil)
+	_ registry.Discovery = (*Registry)(nil)
+)
+
+// ErrServiceInstanceNameEmpty is returned when the service instance name is empty.
+var ErrServiceInstanceNameEmpty = errors.New("nacos: ServiceInstance.Name can not be empty")
+
+const (
+	metadataID      = "id"
+	metadataKind    = "kind"
+	metadataVersion = "version"
+)
+
+type Option func(o *options)
+
+type options struct {
+	group     string
+	cluster   string
+	weight    float64
+	ephemeral bool
+}
+
+// WithGroup with nacos group.
+func WithGroup(group string) Option {
+	return func(o *options) {
+		o.group = group
+	}
+}
+
+// WithCluster with nacos cluster.
+func WithCluster(cluster string) Option {
+	return func(o *options) {
+		o.cluster = cluster
+	}
+}
+
+// WithWeight with nacos instance weight.
+func WithWeight(weight float64) Option {
+	return func(o *options) {
+		o.weight = weight
+	}
+}
+
+// WithEphemeral with nacos ephemeral instance.
+func WithEphemeral(ephemeral bool) Option {
+	return func(o *options) {
+		o.ephemeral = ephemeral
+	}
+}
+
+// Registry is nacos naming registry.
+type Registry struct {
+	opts options
+	cli  naming_client.INamingClient
+}
+
+// New creates a nacos registry with the naming client.
+func New(cli naming_client.INamingClient, opts ...Option) *Registry {
+	_options := options{
+		group:     "DEFAULT_GROUP",
+		cluster:   "DEFAULT",
+		weight:    100,
+		ephemeral: true,
+	}
+	for _, o := range opts {
+		o(&_options)
+	}
+	return &Registry{
+		opts: _options,
+		cli:  cli,
+	}
+}
+
+// Register the registration, one nacos instance is registered for each endpoint.
+func (r *Registry) Register(_ context.Context, si *registry.ServiceInstance) error {
+	if si.Name == "" {
+		return ErrServiceInstanceNameEmpty
+	}
+	for _, endpoint := range si.Endpoints {
+		host, port, scheme, err := parseEndpoint(endpoint)
+		if err != nil {
+			return err
+		}
+
+		md := make(map[string]string, len(si.Metadata)+3)
+		for k, v := range si.Metadata {
+			md[k] = v
+		}
+		md[metadataID] = si.ID
+		md[metadataKind] = scheme
+		md[metadataVersion] = si.Version
+
+		_, err = r.cli.RegisterInstance(vo.RegisterInstanceParam{
+			Ip:          host,
+			Port:        port,
+			ServiceName: si.Name,
+			Weight:      r.opts.weight,
+			Enable:      true,
+			Healthy:     true,
+			Ephemeral:   r.opts.ephemeral,
+			Metadata:    md,
+			ClusterName: r.opts.cluster,
+			GroupName:   r.opts.group,
+		})
+		if err != nil {
+			return fmt.Errorf("nacos: register instance %s error: %w", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// Deregister the registration.
+func (r *Registry) Deregister(_ context.Context, si *registry.ServiceInstance) error {
+	for _, endpoint := range si.Endpoints {
+		host, port, _, err := parseEndpoint(endpoint)
+		if err != nil {
+			return err
+		}
+		_, err = r.cli.DeregisterInstance(vo.DeregisterInstanceParam{
+			Ip:          host,
+			Port:        port,
+			ServiceName: si.Name,
+			GroupName:   r.opts.group,
+			Cluster:     r.opts.cluster,
+			Ephemeral:   r.opts.ephemeral,
+		})
+		if err != nil {
+			return fmt.Errorf("nacos: deregister instance %s error: %w", endpoint, err)
+		}
+	}
+	return nil
+}
+
+// GetService return the healthy service instances in memory according to the service name.
+func (r *Registry) GetService(_ context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	instances, err := r.cli.SelectInstances(vo.SelectInstancesParam{
+		ServiceName: serviceName,
+		GroupName:   r.opts.group,
+		Clusters:    []string{r.opts.cluster},
+		HealthyOnly: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toServiceInstances(serviceName, instances), nil
+}
+
+// Watch creates a watcher according to the service name.
+func (r *Registry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	return newWatcher(ctx, r, serviceName)
+}
+
+// Healthy checks the connection to the nacos server.
+func (r *Registry) Healthy() bool {
+	return r.cli.ServerHealthy()
+}
+
+// Close closes the naming client.
+func (r *Registry) Close() {
+	r.cli.CloseClient()
+}
+
+func parseEndpoint(endpoint string) (host string, port uint64, scheme string, err error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", 0, "", err
+	}
+	host, p, err := net.SplitHostPort(u.Host)
+	if err != nil {
+		return "", 0, "", err
+	}
+	port, err = strconv.ParseUint(p, 10, 16)
+	if err != nil {
+		return "", 0, "", err
+	}
+	return host, port, u.Scheme, nil
+}
+
+func toServiceInstances(serviceName string, instances []model.Instance) []*registry.ServiceInstance {
+	items := make([]*registry.ServiceInstance, 0, len(instances))
+	for _, in := range instances {
+		if !in.Enable || !in.Healthy {
+			continue
+		}
+		kind := in.Metadata[metadataKind]
+		if kind == "" {
+			kind = "grpc"
+		}
+		id := in.Metadata[metadataID]
+		if id == "" {
+			id = in.InstanceId
+		}
+		items = append(items, &registry.ServiceInstance{
+			ID:        id,
+			Name:      serviceName,
+			Version:   in.Metadata[metadataVersion],
+			Metadata:  in.Metadata,
+			Endpoints: []string{fmt.Sprintf("%s://%s", kind, net.JoinHostPort(in.Ip, strconv.FormatUint(in.Port, 10)))},
+		})
+	}
+	return items
+}
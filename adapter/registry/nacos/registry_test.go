@@ -0,0 +1,154 @@
+package nacos
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/nacos-group/nacos-sdk-go/v2/clients/naming_client"
+	"github.com/nacos-group/nacos-sdk-go/v2/model"
+	"github.com/nacos-group/nacos-sdk-go/v2/vo"
+)
+
+// fakeNamingClient keeps the instances in memory.
+type fakeNamingClient struct {
+	naming_client.INamingClient
+
+	mu        sync.Mutex
+	instances []model.Instance
+	callback  func([]model.Instance, error)
+	groups    []string
+}
+
+func (c *fakeNamingClient) RegisterInstance(param vo.RegisterInstanceParam) (bool, error) {
+	c.mu.Lock()
+	c.instances = append(c.instances, model.Instance{
+		Ip:          param.Ip,
+		Port:        param.Port,
+		Weight:      param.Weight,
+		Enable:      param.Enable,
+		Healthy:     param.Healthy,
+		Ephemeral:   param.Ephemeral,
+		Metadata:    param.Metadata,
+		ClusterName: param.ClusterName,
+		ServiceName: param.ServiceName,
+	})
+	c.groups = append(c.groups, param.GroupName)
+	instances, callback := c.instances, c.callback
+	c.mu.Unlock()
+	if callback != nil {
+		callback(instances, nil)
+	}
+	return true, nil
+}
+
+func (c *fakeNamingClient) DeregisterInstance(param vo.DeregisterInstanceParam) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, in := range c.instances {
+		if in.Ip == param.Ip && in.Port == param.Port {
+			c.instances = append(c.instances[:i], c.instances[i+1:]...)
+			break
+		}
+	}
+	return true, nil
+}
+
+func (c *fakeNamingClient) SelectInstances(vo.SelectInstancesParam) ([]model.Instance, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]model.Instance(nil), c.instances...), nil
+}
+
+func (c *fakeNamingClient) Subscribe(param *vo.SubscribeParam) error {
+	c.mu.Lock()
+	c.callback = param.SubscribeCallback
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *fakeNamingClient) Unsubscribe(*vo.SubscribeParam) error {
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	cli := &fakeNamingClient{}
+	r := New(cli, WithGroup("next"), WithWeight(10), WithEphemeral(false))
+
+	si := &registry.ServiceInstance{
+		ID:        "1",
+		Name:      "greeter",
+		Version:   "v1.0.0",
+		Metadata:  map[string]string{"region": "sh"},
+		Endpoints: []string{"grpc://127.0.0.1:9000", "http://127.0.0.1:8000"},
+	}
+	if err := r.Register(context.Background(), si); err != nil {
+		t.Fatal(err)
+	}
+	if len(cli.instances) != 2 || cli.groups[0] != "next" || cli.instances[0].Weight != 10 || cli.instances[0].Ephemeral {
+		t.Fatalf("unexpected instances: %+v", cli.instances)
+	}
+
+	services, err := r.GetService(context.Background(), "greeter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 2 {
+		t.Fatalf("expect 2 instances, got %d", len(services))
+	}
+	s := services[0]
+	if s.ID != "1" || s.Version != "v1.0.0" || s.Metadata["region"] != "sh" || s.Endpoints[0] != "grpc://127.0.0.1:9000" {
+		t.Fatalf("unexpected instance: %+v", s)
+	}
+
+	if err = r.Deregister(context.Background(), si); err != nil {
+		t.Fatal(err)
+	}
+	if len(cli.instances) != 0 {
+		t.Fatalf("expect no instances, got %+v", cli.instances)
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	cli := &fakeNamingClient{}
+	r := New(cli)
+
+	w, err := r.Watch(context.Background(), "greeter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = w.Stop()
+	}()
+
+	services, err := w.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 0 {
+		t.Fatalf("expect no instances, got %d", len(services))
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		_ = r.Register(context.Background(), &registry.ServiceInstance{
+			ID:        "1",
+			Name:      "greeter",
+			Endpoints: []string{"grpc://127.0.0.1:9000"},
+		})
+	}()
+	services, err = w.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 1 || services[0].Endpoints[0] != "grpc://127.0.0.1:9000" {
+		t.Fatalf("unexpected instances: %+v", services)
+	}
+
+	_ = w.Stop()
+	if _, err = w.Next(); err == nil {
+		t.Fatal("expect error after stop")
+	}
+}
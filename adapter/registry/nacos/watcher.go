@@ -0,0 +1,74 @@
+package nacos
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/nacos-group/nacos-sdk-go/v2/model"
+	"github.com/nacos-group/nacos-sdk-go/v2/vo"
+)
+
+var _ registry.Watcher = (*watcher)(nil)
+
+type watcher struct {
+	serviceName string
+	registry    *Registry
+	param       *vo.SubscribeParam
+
+	first  bool
+	event  chan []model.Instance
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newWatcher(ctx context.Context, r *Registry, serviceName string) (*watcher, error) {
+	w := &watcher{
+		serviceName: serviceName,
+		registry:    r,
+		first:       true,
+		event:       make(chan []model.Instance, 1),
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+	w.param = &vo.SubscribeParam{
+		ServiceName: serviceName,
+		GroupName:   r.opts.group,
+		Clusters:    []string{r.opts.cluster},
+		SubscribeCallback: func(instances []model.Instance, err error) {
+			if err != nil {
+				return
+			}
+			// only the latest instances are kept
+			select {
+			case <-w.event:
+			default:
+			}
+			w.event <- instances
+		},
+	}
+	if err := r.cli.Subscribe(w.param); err != nil {
+		w.cancel()
+		return nil, err
+	}
+	return w, nil
+}
+
+// Next returns the instances at the first call, then blocks until the service changed.
+func (w *watcher) Next() ([]*registry.ServiceInstance, error) {
+	if w.first {
+		w.first = false
+		return w.registry.GetService(w.ctx, w.serviceName)
+	}
+
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case instances := <-w.event:
+		return toServiceInstances(w.serviceName, instances), nil
+	}
+}
+
+// Stop stops the watcher.
+func (w *watcher) Stop() error {
+	w.cancel()
+	return w.registry.cli.Unsubscribe(w.param)
+}
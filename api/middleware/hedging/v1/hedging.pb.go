@@ -0,0 +1,198 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v4.25.3
+// source: middleware/hedging/v1/hedging.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Hedging middleware config, a hedge request is sent to another node
+// when the first has not answered within the delay, the first successful reply wins.
+type Hedging struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delay *durationpb.Duration `protobuf:"bytes,1,opt,name=delay,proto3" json:"delay,omitempty"` // fixed hedge delay, default: 100ms
+	// the delay is the percentile of the client duration histogram of the operation, e.g. 0.95,
+	// the fixed delay is used until enough requests are observed.
+	Percentile   float64  `protobuf:"fixed64,2,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	MinSamples   int32    `protobuf:"varint,3,opt,name=min_samples,json=minSamples,proto3" json:"min_samples,omitempty"`         // the requests observed before the percentile is used, default: 100
+	MaxPerSecond int32    `protobuf:"varint,4,opt,name=max_per_second,json=maxPerSecond,proto3" json:"max_per_second,omitempty"` // cap on hedges per second, default: 10
+	Operations   []string `protobuf:"bytes,5,rep,name=operations,proto3" json:"operations,omitempty"`                            // glob patterns of the idempotent operations hedged, default: none, only the idempotent HTTP methods
+}
+
+func (x *Hedging) Reset() {
+	*x = Hedging{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleware_hedging_v1_hedging_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hedging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hedging) ProtoMessage() {}
+
+func (x *Hedging) ProtoReflect() protoreflect.Message {
+	mi := &file_middleware_hedging_v1_hedging_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hedging.ProtoReflect.Descriptor instead.
+func (*Hedging) Descriptor() ([]byte, []int) {
+	return file_middleware_hedging_v1_hedging_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Hedging) GetDelay() *durationpb.Duration {
+	if x != nil {
+		return x.Delay
+	}
+	return nil
+}
+
+func (x *Hedging) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+func (x *Hedging) GetMinSamples() int32 {
+	if x != nil {
+		return x.MinSamples
+	}
+	return 0
+}
+
+func (x *Hedging) GetMaxPerSecond() int32 {
+	if x != nil {
+		return x.MaxPerSecond
+	}
+	return 0
+}
+
+func (x *Hedging) GetOperations() []string {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+var File_middleware_hedging_v1_hedging_proto protoreflect.FileDescriptor
+
+var file_middleware_hedging_v1_hedging_proto_rawDesc = []byte{
+	0x0a, 0x23, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x68, 0x65, 0x64,
+	0x67, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1a, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x6d, 0x69, 0x64, 0x64,
+	0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x68, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x2e, 0x76,
+	0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0xc1, 0x01, 0x0a, 0x07, 0x48, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x12, 0x2f, 0x0a,
+	0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x1e,
+	0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x72, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x78, 0x74, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2f, 0x6e, 0x65,
+	0x78, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72,
+	0x65, 0x2f, 0x68, 0x65, 0x64, 0x67, 0x69, 0x6e, 0x67, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_middleware_hedging_v1_hedging_proto_rawDescOnce sync.Once
+	file_middleware_hedging_v1_hedging_proto_rawDescData = file_middleware_hedging_v1_hedging_proto_rawDesc
+)
+
+func file_middleware_hedging_v1_hedging_proto_rawDescGZIP() []byte {
+	file_middleware_hedging_v1_hedging_proto_rawDescOnce.Do(func() {
+		file_middleware_hedging_v1_hedging_proto_rawDescData = protoimpl.X.CompressGZIP(file_middleware_hedging_v1_hedging_proto_rawDescData)
+	})
+	return file_middleware_hedging_v1_hedging_proto_rawDescData
+}
+
+var file_middleware_hedging_v1_hedging_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_middleware_hedging_v1_hedging_proto_goTypes = []interface{}{
+	(*Hedging)(nil),             // 0: next.middleware.hedging.v1.Hedging
+	(*durationpb.Duration)(nil), // 1: google.protobuf.Duration
+}
+var file_middleware_hedging_v1_hedging_proto_depIdxs = []int32{
+	1, // 0: next.middleware.hedging.v1.Hedging.delay:type_name -> google.protobuf.Duration
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_middleware_hedging_v1_hedging_proto_init() }
+func file_middleware_hedging_v1_hedging_proto_init() {
+	if File_middleware_hedging_v1_hedging_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_middleware_hedging_v1_hedging_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hedging); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_middleware_hedging_v1_hedging_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_middleware_hedging_v1_hedging_proto_goTypes,
+		DependencyIndexes: file_middleware_hedging_v1_hedging_proto_depIdxs,
+		MessageInfos:      file_middleware_hedging_v1_hedging_proto_msgTypes,
+	}.Build()
+	File_middleware_hedging_v1_hedging_proto = out.File
+	file_middleware_hedging_v1_hedging_proto_rawDesc = nil
+	file_middleware_hedging_v1_hedging_proto_goTypes = nil
+	file_middleware_hedging_v1_hedging_proto_depIdxs = nil
+}
@@ -0,0 +1,208 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v4.25.3
+// source: middleware/hmac/v1/hmac.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HMAC request signing middleware config,
+// the client signs the timestamp, nonce, method, path and body hash with HMAC-SHA256.
+// The stream messages are not signed, only the opening of the stream is authenticated.
+type HMAC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	KeyId string            `protobuf:"bytes,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`                                                                          // client: the key signing the requests
+	Keys  map[string]string `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // the secrets by key id, the server accepts all of them
+	// the config key watched to rotate the keys without restart, the value is the HMAC message, e.g. hmac,
+	// the watch starts on the first request signed or verified
+	WatchKey string `protobuf:"bytes,3,opt,name=watch_key,json=watchKey,proto3" json:"watch_key,omitempty"`
+	// server: the accepted timestamp window, default: 5m.
+	// The nonces are remembered in memory for twice the window and kept across reloads,
+	// they are not shared by the replicas, a request replayed to another replica is not rejected.
+	ClockSkew       *durationpb.Duration `protobuf:"bytes,4,opt,name=clock_skew,json=clockSkew,proto3" json:"clock_skew,omitempty"`
+	AllowOperations []string             `protobuf:"bytes,5,rep,name=allow_operations,json=allowOperations,proto3" json:"allow_operations,omitempty"` // server: glob patterns of the operations skipping verification
+}
+
+func (x *HMAC) Reset() {
+	*x = HMAC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleware_hmac_v1_hmac_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HMAC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HMAC) ProtoMessage() {}
+
+func (x *HMAC) ProtoReflect() protoreflect.Message {
+	mi := &file_middleware_hmac_v1_hmac_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HMAC.ProtoReflect.Descriptor instead.
+func (*HMAC) Descriptor() ([]byte, []int) {
+	return file_middleware_hmac_v1_hmac_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HMAC) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *HMAC) GetKeys() map[string]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *HMAC) GetWatchKey() string {
+	if x != nil {
+		return x.WatchKey
+	}
+	return ""
+}
+
+func (x *HMAC) GetClockSkew() *durationpb.Duration {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return nil
+}
+
+func (x *HMAC) GetAllowOperations() []string {
+	if x != nil {
+		return x.AllowOperations
+	}
+	return nil
+}
+
+var File_middleware_hmac_v1_hmac_proto protoreflect.FileDescriptor
+
+var file_middleware_hmac_v1_hmac_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x68, 0x6d, 0x61,
+	0x63, 0x2f, 0x76, 0x31, 0x2f, 0x68, 0x6d, 0x61, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x17, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65,
+	0x2e, 0x68, 0x6d, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x95, 0x02, 0x0a, 0x04, 0x48, 0x4d, 0x41,
+	0x43, 0x12, 0x15, 0x0a, 0x06, 0x6b, 0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x6b, 0x65, 0x79, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x6d, 0x69,
+	0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2e, 0x68, 0x6d, 0x61, 0x63, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x4d, 0x41, 0x43, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68, 0x4b,
+	0x65, 0x79, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x73, 0x6b, 0x65, 0x77,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x6b, 0x65, 0x77, 0x12, 0x29, 0x0a, 0x10,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x37, 0x0a, 0x09, 0x4b, 0x65, 0x79, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e,
+	0x65, 0x78, 0x74, 0x6d, 0x69, 0x63, 0x72, 0x6f, 0x2f, 0x6e, 0x65, 0x78, 0x74, 0x2f, 0x61, 0x70,
+	0x69, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x68, 0x6d, 0x61,
+	0x63, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_middleware_hmac_v1_hmac_proto_rawDescOnce sync.Once
+	file_middleware_hmac_v1_hmac_proto_rawDescData = file_middleware_hmac_v1_hmac_proto_rawDesc
+)
+
+func file_middleware_hmac_v1_hmac_proto_rawDescGZIP() []byte {
+	file_middleware_hmac_v1_hmac_proto_rawDescOnce.Do(func() {
+		file_middleware_hmac_v1_hmac_proto_rawDescData = protoimpl.X.CompressGZIP(file_middleware_hmac_v1_hmac_proto_rawDescData)
+	})
+	return file_middleware_hmac_v1_hmac_proto_rawDescData
+}
+
+var file_middleware_hmac_v1_hmac_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_middleware_hmac_v1_hmac_proto_goTypes = []interface{}{
+	(*HMAC)(nil),                // 0: next.middleware.hmac.v1.HMAC
+	nil,                         // 1: next.middleware.hmac.v1.HMAC.KeysEntry
+	(*durationpb.Duration)(nil), // 2: google.protobuf.Duration
+}
+var file_middleware_hmac_v1_hmac_proto_depIdxs = []int32{
+	1, // 0: next.middleware.hmac.v1.HMAC.keys:type_name -> next.middleware.hmac.v1.HMAC.KeysEntry
+	2, // 1: next.middleware.hmac.v1.HMAC.clock_skew:type_name -> google.protobuf.Duration
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_middleware_hmac_v1_hmac_proto_init() }
+func file_middleware_hmac_v1_hmac_proto_init() {
+	if File_middleware_hmac_v1_hmac_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_middleware_hmac_v1_hmac_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HMAC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_middleware_hmac_v1_hmac_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_middleware_hmac_v1_hmac_proto_goTypes,
+		DependencyIndexes: file_middleware_hmac_v1_hmac_proto_depIdxs,
+		MessageInfos:      file_middleware_hmac_v1_hmac_proto_msgTypes,
+	}.Build()
+	File_middleware_hmac_v1_hmac_proto = out.File
+	file_middleware_hmac_v1_hmac_proto_rawDesc = nil
+	file_middleware_hmac_v1_hmac_proto_goTypes = nil
+	file_middleware_hmac_v1_hmac_proto_depIdxs = nil
+}
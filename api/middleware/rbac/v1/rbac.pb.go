@@ -0,0 +1,292 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v4.25.3
+// source: middleware/rbac/v1/rbac.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RBAC authorization middleware config, it runs after the auth middleware.
+type RBAC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*Rule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	// the config key watched to hot reload the rules, the value is the RBAC message, e.g. rbac
+	WatchKey string `protobuf:"bytes,2,opt,name=watch_key,json=watchKey,proto3" json:"watch_key,omitempty"`
+	// allow the operations not selected by any rule, default: denied
+	DefaultAllow bool   `protobuf:"varint,3,opt,name=default_allow,json=defaultAllow,proto3" json:"default_allow,omitempty"`
+	RolesClaim   string `protobuf:"bytes,4,opt,name=roles_claim,json=rolesClaim,proto3" json:"roles_claim,omitempty"`    // the claim of the roles, default: roles
+	ScopesClaim  string `protobuf:"bytes,5,opt,name=scopes_claim,json=scopesClaim,proto3" json:"scopes_claim,omitempty"` // the claim of the scopes, a space separated string or a list, default: scope
+}
+
+func (x *RBAC) Reset() {
+	*x = RBAC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleware_rbac_v1_rbac_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RBAC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RBAC) ProtoMessage() {}
+
+func (x *RBAC) ProtoReflect() protoreflect.Message {
+	mi := &file_middleware_rbac_v1_rbac_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RBAC.ProtoReflect.Descriptor instead.
+func (*RBAC) Descriptor() ([]byte, []int) {
+	return file_middleware_rbac_v1_rbac_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RBAC) GetRules() []*Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *RBAC) GetWatchKey() string {
+	if x != nil {
+		return x.WatchKey
+	}
+	return ""
+}
+
+func (x *RBAC) GetDefaultAllow() bool {
+	if x != nil {
+		return x.DefaultAllow
+	}
+	return false
+}
+
+func (x *RBAC) GetRolesClaim() string {
+	if x != nil {
+		return x.RolesClaim
+	}
+	return ""
+}
+
+func (x *RBAC) GetScopesClaim() string {
+	if x != nil {
+		return x.ScopesClaim
+	}
+	return ""
+}
+
+// Rule grants the selected operations to the requests matching all the non-empty conditions,
+// the rules of the most specific selector are evaluated, and one of them must grant the request.
+type Rule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the operation selector, the same as Server.Use:
+	//   - '/*'
+	//   - '/helloworld.v1.Greeter/*'
+	//   - '/helloworld.v1.Greeter/SayHello'
+	Selector string   `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	Roles    []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`   // one of the roles is required
+	Scopes   []string `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"` // one of the scopes is required
+	// glob patterns of the caller service, one of them is required.
+	// The caller is the x-md-local-caller metadata set by the client, it is not authenticated,
+	// so the callers only narrow the grant of the roles or scopes from a verified token.
+	Callers []string `protobuf:"bytes,4,rep,name=callers,proto3" json:"callers,omitempty"`
+}
+
+func (x *Rule) Reset() {
+	*x = Rule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_middleware_rbac_v1_rbac_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rule) ProtoMessage() {}
+
+func (x *Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_middleware_rbac_v1_rbac_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rule.ProtoReflect.Descriptor instead.
+func (*Rule) Descriptor() ([]byte, []int) {
+	return file_middleware_rbac_v1_rbac_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Rule) GetSelector() string {
+	if x != nil {
+		return x.Selector
+	}
+	return ""
+}
+
+func (x *Rule) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Rule) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *Rule) GetCallers() []string {
+	if x != nil {
+		return x.Callers
+	}
+	return nil
+}
+
+var File_middleware_rbac_v1_rbac_proto protoreflect.FileDescriptor
+
+var file_middleware_rbac_v1_rbac_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65, 0x2f, 0x72, 0x62, 0x61,
+	0x63, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x17, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61, 0x72, 0x65,
+	0x2e, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x22, 0xc1, 0x01, 0x0a, 0x04, 0x52, 0x42, 0x41,
+	0x43, 0x12, 0x33, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1d, 0x2e, 0x6e, 0x65, 0x78, 0x74, 0x2e, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x77, 0x61,
+	0x72, 0x65, 0x2e, 0x72, 0x62, 0x61, 0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6c, 0x65, 0x52,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x4b, 0x65, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x6f, 0x6c, 0x65,
+	0x73, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x6f, 0x6c, 0x65, 0x73, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x63, 0x6f,
+	0x70, 0x65, 0x73, 0x5f, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x22, 0x6a, 0x0a, 0x04,
+	0x52, 0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x61, 0x6c, 0x6c, 0x65, 0x72, 0x73, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x65, 0x78, 0x74, 0x6d, 0x69, 0x63, 0x72, 0x6f,
+	0x2f, 0x6e, 0x65, 0x78, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x69, 0x64, 0x64, 0x6c, 0x65,
+	0x77, 0x61, 0x72, 0x65, 0x2f, 0x72, 0x62, 0x61, 0x63, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_middleware_rbac_v1_rbac_proto_rawDescOnce sync.Once
+	file_middleware_rbac_v1_rbac_proto_rawDescData = file_middleware_rbac_v1_rbac_proto_rawDesc
+)
+
+func file_middleware_rbac_v1_rbac_proto_rawDescGZIP() []byte {
+	file_middleware_rbac_v1_rbac_proto_rawDescOnce.Do(func() {
+		file_middleware_rbac_v1_rbac_proto_rawDescData = protoimpl.X.CompressGZIP(file_middleware_rbac_v1_rbac_proto_rawDescData)
+	})
+	return file_middleware_rbac_v1_rbac_proto_rawDescData
+}
+
+var file_middleware_rbac_v1_rbac_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_middleware_rbac_v1_rbac_proto_goTypes = []interface{}{
+	(*RBAC)(nil), // 0: next.middleware.rbac.v1.RBAC
+	(*Rule)(nil), // 1: next.middleware.rbac.v1.Rule
+}
+var file_middleware_rbac_v1_rbac_proto_depIdxs = []int32{
+	1, // 0: next.middleware.rbac.v1.RBAC.rules:type_name -> next.middleware.rbac.v1.Rule
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_middleware_rbac_v1_rbac_proto_init() }
+func file_middleware_rbac_v1_rbac_proto_init() {
+	if File_middleware_rbac_v1_rbac_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_middleware_rbac_v1_rbac_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RBAC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_middleware_rbac_v1_rbac_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Rule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_middleware_rbac_v1_rbac_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_middleware_rbac_v1_rbac_proto_goTypes,
+		DependencyIndexes: file_middleware_rbac_v1_rbac_proto_depIdxs,
+		MessageInfos:      file_middleware_rbac_v1_rbac_proto_msgTypes,
+	}.Build()
+	File_middleware_rbac_v1_rbac_proto = out.File
+	file_middleware_rbac_v1_rbac_proto_rawDesc = nil
+	file_middleware_rbac_v1_rbac_proto_goTypes = nil
+	file_middleware_rbac_v1_rbac_proto_depIdxs = nil
+}
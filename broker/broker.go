@@ -17,6 +17,16 @@ type Broker interface {
 	String() string
 }
 
+// HealthChecker is implemented by the brokers which can report their health.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// Drainer is implemented by the brokers which can stop consuming and wait for the in-flight handlers.
+type Drainer interface {
+	Drain(ctx context.Context) error
+}
+
 // Handler is used to process messages via a subscription of a topic.
 // The handler is passed a publication interface which contains the
 // message and optional Ack method to acknowledge receipt of the message.
@@ -48,6 +58,23 @@ var (
 	DefaultBroker Broker = NewMemoryBroker()
 )
 
+// Health checks the health of the broker, the brokers not implementing HealthChecker are healthy.
+func Health(ctx context.Context, b Broker) error {
+	if hc, ok := b.(HealthChecker); ok {
+		return hc.Health(ctx)
+	}
+	return nil
+}
+
+// Drain stops consuming new messages and waits for the in-flight handlers until ctx is done,
+// the brokers not implementing Drainer are drained.
+func Drain(ctx context.Context, b Broker) error {
+	if d, ok := b.(Drainer); ok {
+		return d.Drain(ctx)
+	}
+	return nil
+}
+
 func Init(opts ...Option) error {
 	return DefaultBroker.Init(opts...)
 }
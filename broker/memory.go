@@ -20,6 +20,8 @@ type memoryBroker struct {
 	addr string
 	sync.RWMutex
 	connected   bool
+	draining    bool
+	inflight    sync.WaitGroup
 	Subscribers map[string][]*memorySubscriber
 }
 
@@ -85,6 +87,7 @@ func (m *memoryBroker) Connect() error {
 
 	m.addr = addr
 	m.connected = true
+	m.draining = false
 
 	return nil
 }
@@ -102,6 +105,25 @@ func (m *memoryBroker) Disconnect() error {
 	return nil
 }
 
+// Drain stops delivering the messages and waits for the in-flight handlers.
+func (m *memoryBroker) Drain(ctx context.Context) error {
+	m.Lock()
+	m.draining = true
+	m.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.inflight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (m *memoryBroker) Init(opts ...Option) error {
 	for _, o := range opts {
 		o(m.opts)
@@ -115,12 +137,19 @@ func (m *memoryBroker) Publish(ctx context.Context, topic string, msg *Message,
 		m.RUnlock()
 		return errors.New("not connected")
 	}
+	if m.draining {
+		m.RUnlock()
+		return errors.New("draining")
+	}
 
 	subs, ok := m.Subscribers[topic]
-	m.RUnlock()
 	if !ok {
+		m.RUnlock()
 		return nil
 	}
+	m.inflight.Add(1)
+	m.RUnlock()
+	defer m.inflight.Done()
 
 	var v interface{}
 	if m.opts.Codec != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/nextmicro/next/broker"
 )
@@ -53,3 +54,53 @@ func TestMemoryBroker(t *testing.T) {
 		t.Fatalf("Unexpected connect error %v", err)
 	}
 }
+
+func TestMemoryBrokerDrain(t *testing.T) {
+	b := broker.NewMemoryBroker()
+	if err := b.Connect(); err != nil {
+		t.Fatalf("Unexpected connect error %v", err)
+	}
+	defer b.Disconnect()
+
+	started, release := make(chan struct{}), make(chan struct{})
+	if _, err := b.Subscribe("test", func(ctx context.Context, p broker.Event) error {
+		close(started)
+		<-release
+		return nil
+	}); err != nil {
+		t.Fatalf("Unexpected error subscribing %v", err)
+	}
+
+	published := make(chan error, 1)
+	go func() {
+		published <- b.Publish(context.Background(), "test", &broker.Message{Body: []byte("hello")})
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- broker.Drain(context.Background(), b)
+	}()
+	select {
+	case err := <-drained:
+		t.Fatalf("Drain returned before the handler finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-drained; err != nil {
+		t.Fatalf("Unexpected drain error %v", err)
+	}
+	if err := <-published; err != nil {
+		t.Fatalf("Unexpected publish error %v", err)
+	}
+	if err := b.Publish(context.Background(), "test", &broker.Message{}); err == nil {
+		t.Fatal("Expected publish error after drain")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := broker.Drain(ctx, b); err != nil {
+		t.Fatalf("Unexpected drain error %v", err)
+	}
+}
@@ -6,10 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 
 	kConfig "github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/config/env"
-	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/nacos-group/nacos-sdk-go/v2/clients"
 	"github.com/nacos-group/nacos-sdk-go/v2/common/constant"
 	"github.com/nacos-group/nacos-sdk-go/v2/vo"
@@ -20,6 +20,7 @@ import (
 	"github.com/nextmicro/next/api/config/v1"
 	util "github.com/nextmicro/next/internal/pkg/file"
 	kUtil "github.com/nextmicro/next/pkg/env"
+	"github.com/nextmicro/next/pkg/redact"
 )
 
 const (
@@ -40,23 +41,37 @@ type Config struct {
 
 	path     string
 	filename string
+	sources  []*recordSource
+	resolver *resolver
 }
 
+// newConfig returns the kratos config of the sources, the placeholders are expanded by the resolver.
+// The key values loaded last by the sources are recorded for Origins.
+func (c *Config) newConfig(source ...kConfig.Source) kConfig.Config {
+	c.sources = make([]*recordSource, 0, len(source))
+	recorded := make([]kConfig.Source, 0, len(source))
+	for _, src := range source {
+		rs := &recordSource{Source: src}
+		c.sources = append(c.sources, rs)
+		recorded = append(recorded, rs)
+	}
+	return kConfig.New(
+		kConfig.WithSource(recorded...),
+		kConfig.WithResolver(c.resolver.resolve),
+	)
+}
+
+// buildFileSource builds the NEXT_ environment variables source and the config files source,
+// the config files overlay in the order of overlayFiles.
 func (c *Config) buildFileSource() []kConfig.Source {
-	source := make([]kConfig.Source, 0, 3)
+	source := make([]kConfig.Source, 0, 2)
 
 	// env source
 	source = append(source, env.NewSource(kUtil.NextEnvPrefix))
 
-	// base config file source
-	baseFilename := filepath.Join(c.path, _baseConf)
-	if exists, _ := util.Exists(baseFilename); exists {
-		source = append(source, file.NewSource(baseFilename))
-	}
-
-	// custom config file source
-	if exists, _ := util.Exists(c.filename); exists {
-		source = append(source, file.NewSource(c.filename))
+	// config files source: config.yaml, config.<DEPLOY_ENV>.yaml, config.<DEPLOY_COLOR>.yaml and the custom file
+	if files := overlayFiles(c.path, c.filename, kUtil.DeployEnvironment(), kUtil.DeployColor()); len(files) > 0 {
+		source = append(source, newOverlaySource(files...))
 	}
 
 	return source
@@ -69,6 +84,24 @@ func (c *Config) buildNacosSource() ([]kConfig.Source, error) {
 		return []kConfig.Source{}, nil
 	}
 
+	param, err := NacosClientParam(cfg)
+	if err != nil {
+		return nil, err
+	}
+	client, err := clients.NewConfigClient(param)
+
+	adapterNacos.NewNacos(logger.DefaultLogger).SetLogger() // adapter nacos logger
+	if err != nil {
+		return nil, fmt.Errorf("failed to create nacos client, error: %s", err)
+	}
+
+	return []kConfig.Source{
+		nacos.NewConfigSource(client, nacos.WithDataID(cfg.DataId), nacos.WithGroup(cfg.Group), nacos.WithFormat(cfg.Format)),
+	}, nil
+}
+
+// NacosClientParam 构建nacos客户端参数, 配置中心与注册中心共用
+func NacosClientParam(cfg *v1.Nacos) (vo.NacosClientParam, error) {
 	if cfg.GetCacheDir() == "" && kUtil.IsDev() {
 		cfg.CacheDir = fmt.Sprintf("%s/runtime/nacos/cache", kUtil.WorkDir())
 	} else if cfg.GetCacheDir() == "" {
@@ -84,11 +117,11 @@ func (c *Config) buildNacosSource() ([]kConfig.Source, error) {
 		// 判断备份目录是否存在
 		exists, err := util.Exists(cfg.CacheDir)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check backup path: %s, error: %s", cfg.CacheDir, err)
+			return vo.NacosClientParam{}, fmt.Errorf("failed to check backup path: %s, error: %s", cfg.CacheDir, err)
 		}
 		if !exists {
 			if err := os.MkdirAll(cfg.CacheDir, 0755); err != nil {
-				return nil, fmt.Errorf("failed to create backup path: %s, error: %s", cfg.CacheDir, err)
+				return vo.NacosClientParam{}, fmt.Errorf("failed to create backup path: %s, error: %s", cfg.CacheDir, err)
 			}
 		}
 	}
@@ -96,11 +129,11 @@ func (c *Config) buildNacosSource() ([]kConfig.Source, error) {
 		// 判断备份目录是否存在
 		exists, err := util.Exists(cfg.LogDir)
 		if err != nil {
-			return nil, fmt.Errorf("failed to check log dir path: %s, error: %s", cfg.LogDir, err)
+			return vo.NacosClientParam{}, fmt.Errorf("failed to check log dir path: %s, error: %s", cfg.LogDir, err)
 		}
 		if !exists {
 			if err := os.MkdirAll(cfg.LogDir, 0755); err != nil {
-				return nil, fmt.Errorf("failed to create log dir path: %s, error: %s", cfg.CacheDir, err)
+				return vo.NacosClientParam{}, fmt.Errorf("failed to create log dir path: %s, error: %s", cfg.CacheDir, err)
 			}
 		}
 	}
@@ -110,12 +143,12 @@ func (c *Config) buildNacosSource() ([]kConfig.Source, error) {
 		// check we have a port
 		host, port, err := net.SplitHostPort(addr)
 		if err != nil {
-			return nil, err
+			return vo.NacosClientParam{}, err
 		}
 
 		p, err := strconv.ParseUint(port, 10, 64)
 		if err != nil {
-			return nil, err
+			return vo.NacosClientParam{}, err
 		}
 		serverConfigs = append(serverConfigs, *constant.NewServerConfig(host, p, constant.WithContextPath(cfg.ContextPath)))
 	}
@@ -144,38 +177,33 @@ func (c *Config) buildNacosSource() ([]kConfig.Source, error) {
 		constant.WithLogDir(cfg.LogDir),
 		constant.WithOpenKMS(false),
 	)
-	client, err := clients.NewConfigClient(
-		vo.NacosClientParam{
-			ClientConfig:  clientConfig,
-			ServerConfigs: serverConfigs,
-		},
-	)
-
-	adapterNacos.NewNacos(logger.DefaultLogger).SetLogger() // adapter nacos logger
-	if err != nil {
-		return nil, fmt.Errorf("failed to create nacos client, error: %s", err)
-	}
-
-	return []kConfig.Source{
-		nacos.NewConfigSource(client, nacos.WithDataID(cfg.DataId), nacos.WithGroup(cfg.Group), nacos.WithFormat(cfg.Format)),
+	return vo.NacosClientParam{
+		ClientConfig:  clientConfig,
+		ServerConfigs: serverConfigs,
 	}, nil
 }
 
+var adaptLoggerOnce sync.Once
+
 // Init 初始化配置
 func Init(filename string) (kConfig.Config, error) {
 	cc := &Config{
 		path:     filepath.Dir(filename),
 		filename: filename,
+		resolver: newResolver(),
 	}
 
-	kratos.New(logger.DefaultLogger).SetLogger() // adapter kratos logger
+	// mask the resolved secrets in the logs, the logger is replaced by the logger loader later
+	if l := redact.WrapLogger(logger.DefaultLogger); l != logger.DefaultLogger {
+		logger.DefaultLogger = l
+	}
+	// adapter kratos logger, once as the watchers of the previous config may be logging
+	adaptLoggerOnce.Do(kratos.New(logger.DefaultLogger).SetLogger)
 
 	// build file source
 	source := cc.buildFileSource()
 
-	cc.Config = kConfig.New(
-		kConfig.WithSource(source...),
-	)
+	cc.Config = cc.newConfig(source...)
 
 	err := cc.Load()
 	if err != nil {
@@ -186,6 +214,10 @@ func Init(filename string) (kConfig.Config, error) {
 	if err = AppScan(); err != nil {
 		return nil, fmt.Errorf("failed to scan next config, error: %v", err)
 	}
+	// fail fast on the invalid framework config
+	if err = Validate(ApplicationConfig()); err != nil {
+		return nil, err
+	}
 
 	// build Nacos source if needed
 	sources, err := cc.buildNacosSource()
@@ -198,7 +230,7 @@ func Init(filename string) (kConfig.Config, error) {
 			return nil, err
 		}
 		source = append(source, sources...)
-		cc.Config = kConfig.New(kConfig.WithSource(source...))
+		cc.Config = cc.newConfig(source...)
 		err = cc.Load()
 		if err != nil {
 			return nil, fmt.Errorf("failed to load config filename: %s, error: %s", filename, err)
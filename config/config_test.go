@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	kconfig "github.com/go-kratos/kratos/v2/config"
 	"github.com/nextmicro/next/config"
 	"github.com/stretchr/testify/assert"
 )
@@ -91,3 +94,28 @@ func TestBizConfPath(t *testing.T) {
 	path := config.BizConfFile()
 	t.Log(path)
 }
+
+func TestSubscribe(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write("rules: a\n")
+	c, err := config.Init(path)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	var first, second atomic.Int64
+	unsubscribe, err := config.Subscribe("rules", func(string, kconfig.Value) { first.Add(1) })
+	assert.NoError(t, err)
+	_, err = config.Subscribe("rules", func(string, kconfig.Value) { second.Add(1) })
+	assert.NoError(t, err)
+
+	write("rules: b\n")
+	assert.Eventually(t, func() bool { return first.Load() == 1 && second.Load() == 1 }, 3*time.Second, 10*time.Millisecond)
+
+	unsubscribe()
+	write("rules: c\n")
+	assert.Eventually(t, func() bool { return second.Load() == 2 }, 3*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), first.Load())
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"sync"
+
 	"github.com/go-kratos/kratos/v2/config"
 	"github.com/nextmicro/next/pkg/env"
 )
@@ -35,6 +37,60 @@ func Watch(key string, o config.Observer) error {
 	return DefaultConfig.Watch(key, o)
 }
 
+// subscribers are the observers of a key, the config watches the key once for all of them.
+type subscribers struct {
+	mu        sync.RWMutex
+	observers map[*config.Observer]struct{}
+}
+
+func (s *subscribers) notify(key string, value config.Value) {
+	s.mu.RLock()
+	observers := make([]config.Observer, 0, len(s.observers))
+	for o := range s.observers {
+		observers = append(observers, *o)
+	}
+	s.mu.RUnlock()
+	for _, o := range observers {
+		o(key, value)
+	}
+}
+
+var (
+	subscribeMu     sync.Mutex
+	subscribeConfig config.Config
+	subscriptions   map[string]*subscribers
+)
+
+// Subscribe watches config changes like Watch, the observer is removed by the returned unsubscribe,
+// e.g. when the middleware watching the config is replaced by a reload.
+func Subscribe(key string, o config.Observer) (unsubscribe func(), err error) {
+	subscribeMu.Lock()
+	defer subscribeMu.Unlock()
+	if subscribeConfig != DefaultConfig {
+		// the default config is initialized again
+		subscribeConfig = DefaultConfig
+		subscriptions = make(map[string]*subscribers)
+	}
+	s, ok := subscriptions[key]
+	if !ok {
+		s = &subscribers{observers: make(map[*config.Observer]struct{})}
+		if err = DefaultConfig.Watch(key, s.notify); err != nil {
+			return nil, err
+		}
+		subscriptions[key] = s
+	}
+
+	p := &o
+	s.mu.Lock()
+	s.observers[p] = struct{}{}
+	s.mu.Unlock()
+	return func() {
+		s.mu.Lock()
+		delete(s.observers, p)
+		s.mu.Unlock()
+	}, nil
+}
+
 // Close closes the config source.
 func Close() error {
 	return DefaultConfig.Close()
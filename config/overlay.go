@@ -0,0 +1,265 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	kConfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/encoding"
+	util "github.com/nextmicro/next/internal/pkg/file"
+)
+
+// overlayFiles returns the existing config files of dir in the overlay order, the later overrides the earlier:
+//
+//  1. config.yaml
+//  2. config.<DEPLOY_ENV>.yaml
+//  3. config.<DEPLOY_COLOR>.yaml
+//  4. the custom config file
+//
+// The maps are merged key by key, the scalars and the lists of a later file replace the earlier ones as a whole.
+func overlayFiles(dir, filename, deployEnv, deployColor string) []string {
+	names := []string{_baseConf}
+	ext := filepath.Ext(_baseConf)
+	base := strings.TrimSuffix(_baseConf, ext)
+	for _, overlay := range []string{deployEnv, deployColor} {
+		if overlay != "" {
+			names = append(names, base+"."+overlay+ext)
+		}
+	}
+
+	files := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		files = append(files, filepath.Join(dir, name))
+	}
+	if filename != "" {
+		files = append(files, filename)
+	}
+
+	seen := make(map[string]bool, len(files))
+	existing := files[:0]
+	for _, f := range files {
+		f = filepath.Clean(f)
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		if exists, _ := util.Exists(f); exists {
+			existing = append(existing, f)
+		}
+	}
+	return existing
+}
+
+// overlaySource loads the config files in order as one source, a change of any file reloads all of them
+// so the later files keep overriding the earlier ones.
+type overlaySource struct {
+	sources []kConfig.Source
+}
+
+func newOverlaySource(files ...string) kConfig.Source {
+	s := &overlaySource{}
+	for _, f := range files {
+		s.sources = append(s.sources, file.NewSource(f))
+	}
+	return s
+}
+
+func (s *overlaySource) Load() ([]*kConfig.KeyValue, error) {
+	var kvs []*kConfig.KeyValue
+	for _, src := range s.sources {
+		kv, err := src.Load()
+		if err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, kv...)
+	}
+	return kvs, nil
+}
+
+func (s *overlaySource) Watch() (kConfig.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &overlayWatcher{
+		source:  s,
+		changed: make(chan struct{}, 1),
+		errs:    make(chan error),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for _, src := range s.sources {
+		sw, err := src.Watch()
+		if err != nil {
+			_ = w.Stop()
+			return nil, err
+		}
+		w.watchers = append(w.watchers, sw)
+		go w.watch(sw)
+	}
+	return w, nil
+}
+
+type overlayWatcher struct {
+	source   *overlaySource
+	watchers []kConfig.Watcher
+	changed  chan struct{}
+	errs     chan error
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func (w *overlayWatcher) watch(sw kConfig.Watcher) {
+	for {
+		_, err := sw.Next()
+		if w.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			select {
+			case w.errs <- err:
+			case <-w.ctx.Done():
+				return
+			}
+			continue
+		}
+		select {
+		case w.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Next returns all the files once any of them changes.
+func (w *overlayWatcher) Next() ([]*kConfig.KeyValue, error) {
+	select {
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	case err := <-w.errs:
+		return nil, err
+	case <-w.changed:
+		return w.source.Load()
+	}
+}
+
+func (w *overlayWatcher) Stop() error {
+	w.cancel()
+	var errs []error
+	for _, sw := range w.watchers {
+		errs = append(errs, sw.Stop())
+	}
+	return errors.Join(errs...)
+}
+
+// recordSource records the key values of the last load or change of the source, see Origins.
+type recordSource struct {
+	kConfig.Source
+
+	mu  sync.RWMutex
+	kvs []*kConfig.KeyValue
+}
+
+func (s *recordSource) Load() ([]*kConfig.KeyValue, error) {
+	kvs, err := s.Source.Load()
+	if err == nil {
+		s.record(kvs)
+	}
+	return kvs, err
+}
+
+func (s *recordSource) Watch() (kConfig.Watcher, error) {
+	w, err := s.Source.Watch()
+	if err != nil {
+		return nil, err
+	}
+	return &recordWatcher{Watcher: w, source: s}, nil
+}
+
+func (s *recordSource) record(kvs []*kConfig.KeyValue) {
+	s.mu.Lock()
+	s.kvs = kvs
+	s.mu.Unlock()
+}
+
+func (s *recordSource) loaded() []*kConfig.KeyValue {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.kvs
+}
+
+type recordWatcher struct {
+	kConfig.Watcher
+	source *recordSource
+}
+
+func (w *recordWatcher) Next() ([]*kConfig.KeyValue, error) {
+	kvs, err := w.Watcher.Next()
+	if err == nil {
+		w.source.record(kvs)
+	}
+	return kvs, err
+}
+
+// Origins returns the source each effective key of the default config comes from, e.g.
+// {"server.http.addr": "config.dev.yaml"}, as last loaded or reloaded, the files edited
+// but not reloaded yet are not read. The lists are reported as one key, the keys of the
+// NEXT_ environment variables come from "env".
+func Origins() (map[string]string, error) {
+	c, ok := DefaultConfig.(*Config)
+	if !ok {
+		return nil, errors.New("config: the origins are only tracked by Init")
+	}
+	return origins(c.sources)
+}
+
+func origins(sources []*recordSource) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, src := range sources {
+		for _, kv := range src.loaded() {
+			if kv.Format == "" {
+				// the environment variables are flat keys
+				out[kv.Key] = "env"
+				continue
+			}
+			codec := encoding.GetCodec(kv.Format)
+			if codec == nil {
+				return nil, fmt.Errorf("config: unsupported key: %s format: %s", kv.Key, kv.Format)
+			}
+			values := make(map[string]interface{})
+			if err := codec.Unmarshal(kv.Value, &values); err != nil {
+				return nil, fmt.Errorf("config: failed to decode %s: %v", kv.Key, err)
+			}
+			flatten("", values, kv.Key, out)
+		}
+	}
+	return out, nil
+}
+
+func flatten(prefix string, values map[string]interface{}, origin string, out map[string]string) {
+	for k, value := range values {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(key, v, origin, out)
+		case map[interface{}]interface{}:
+			m := make(map[string]interface{}, len(v))
+			for mk, mv := range v {
+				m[fmt.Sprint(mk)] = mv
+			}
+			flatten(key, m, origin, out)
+		default:
+			// a scalar or a list replaces the earlier value as a whole
+			for existing := range out {
+				if strings.HasPrefix(existing, key+".") {
+					delete(out, existing)
+				}
+			}
+			out[key] = origin
+		}
+	}
+}
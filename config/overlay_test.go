@@ -0,0 +1,88 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nextmicro/next/config"
+	"github.com/nextmicro/next/pkg/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOverlay(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("config.yaml", `
+name: base
+server:
+  http:
+    addr: 0.0.0.0:8000
+    timeout: 1s
+broker:
+  addrs: [base:9092, base2:9092]
+`)
+	write("config."+env.DeployEnvironment()+".yaml", `
+server:
+  http:
+    addr: 0.0.0.0:8080
+broker:
+  addrs: [env:9092]
+`)
+	write("app.yaml", `
+name: app
+`)
+
+	c, err := config.Init(filepath.Join(dir, "app.yaml"))
+	assert.NoError(t, err)
+	defer c.Close()
+
+	app := config.ApplicationConfig()
+	assert.Equal(t, "app", app.GetName())
+	assert.Equal(t, "0.0.0.0:8080", app.GetServer().GetHttp().GetAddr())
+	assert.Equal(t, time.Second, app.GetServer().GetHttp().GetTimeout().AsDuration())
+	assert.Equal(t, []string{"env:9092"}, app.GetBroker().GetAddrs())
+
+	origins, err := config.Origins()
+	assert.NoError(t, err)
+	assert.Equal(t, "app.yaml", origins["name"])
+	assert.Equal(t, "config.yaml", origins["server.http.timeout"])
+	assert.Equal(t, "config."+env.DeployEnvironment()+".yaml", origins["server.http.addr"])
+	assert.Equal(t, "config."+env.DeployEnvironment()+".yaml", origins["broker.addrs"])
+
+	// the overlay keeps overriding the reloaded base file
+	write("config.yaml", `
+name: base
+server:
+  http:
+    addr: 0.0.0.0:9000
+    timeout: 2s
+`)
+	assert.Eventually(t, func() bool {
+		s, _ := c.Value("server.http.timeout").String()
+		return s == "2s"
+	}, 3*time.Second, 10*time.Millisecond)
+	addr, err := c.Value("server.http.addr").String()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.0.0.0:8080", addr)
+}
+
+func TestOriginsLoaded(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("name: app\n"), 0o600))
+
+	c, err := config.Init(path)
+	assert.NoError(t, err)
+	// not watching, the edit below is not reloaded
+	assert.NoError(t, c.Close())
+
+	assert.NoError(t, os.WriteFile(path, []byte("name: app\nversion: v2\n"), 0o600))
+	origins, err := config.Origins()
+	assert.NoError(t, err)
+	assert.Equal(t, "app.yaml", origins["name"])
+	assert.NotContains(t, origins, "version")
+}
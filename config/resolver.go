@@ -0,0 +1,302 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nextmicro/next/pkg/redact"
+)
+
+// ErrSecretNotFound is the secret not found by the provider.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider provides the secrets of the ${secret:provider/key} placeholders.
+type SecretProvider interface {
+	Secret(key string) (string, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]SecretProvider)
+)
+
+// RegisterSecretProvider registers the secret provider with name, it must be registered before Init.
+func RegisterSecretProvider(name string, p SecretProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = p
+}
+
+func secretProvider(name string) (SecretProvider, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[name]
+	return p, ok
+}
+
+type fileSecretProvider struct {
+	dir string
+}
+
+// NewFileSecretProvider returns a secret provider reading the key file of dir, e.g. the secrets mounted at /run/secrets.
+func NewFileSecretProvider(dir string) SecretProvider {
+	return &fileSecretProvider{dir: dir}
+}
+
+func (p *fileSecretProvider) Secret(key string) (string, error) {
+	if !filepath.IsLocal(key) {
+		return "", fmt.Errorf("invalid secret key %q", key)
+	}
+	b, err := os.ReadFile(filepath.Join(p.dir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+var placeholder = regexp.MustCompile(`\${(.*?)}`)
+
+// resolver expands the placeholders of the config values, a default follows the ':' after the reference:
+//
+//   - ${env:NAME} or ${env:NAME:default}, the environment variable
+//   - ${file:/run/secrets/x}, the trimmed content of the file
+//   - ${secret:provider/key}, the secret of the registered provider
+//   - ${key} or ${key:default}, the value of the other config key, as kratos does
+//
+// The values of the file and secret placeholders are marked as secrets, they are redacted by Dump and the logs.
+// A key placeholder referring to a secret key is a secret too. The keys are resolved in the sorted order.
+type resolver struct {
+	mu      sync.Mutex
+	secrets map[string]struct{}
+}
+
+func newResolver() *resolver {
+	return &resolver{secrets: make(map[string]struct{})}
+}
+
+func (r *resolver) resolve(input map[string]interface{}) error {
+	var resolve func(path string, sub map[string]interface{}) error
+	resolve = func(path string, sub map[string]interface{}) error {
+		keys := make([]string, 0, len(sub))
+		for k := range sub {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := k
+			if path != "" {
+				key = path + "." + k
+			}
+			switch vt := sub[k].(type) {
+			case string:
+				s, err := r.expand(input, key, vt)
+				if err != nil {
+					return err
+				}
+				sub[k] = s
+			case map[string]interface{}:
+				if err := resolve(key, vt); err != nil {
+					return err
+				}
+			case []interface{}:
+				for i, iface := range vt {
+					item := fmt.Sprintf("%s[%d]", key, i)
+					switch it := iface.(type) {
+					case string:
+						s, err := r.expand(input, item, it)
+						if err != nil {
+							return err
+						}
+						vt[i] = s
+					case map[string]interface{}:
+						if err := resolve(item, it); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+		return nil
+	}
+	return resolve("", input)
+}
+
+// maxDepth is the maximum depth of the key placeholders referring to the other key placeholders.
+const maxDepth = 8
+
+func (r *resolver) expand(input map[string]interface{}, key, s string) (string, error) {
+	out, secret, err := r.expandDepth(input, s, 0)
+	if err != nil {
+		return "", fmt.Errorf("config: resolve %s: %w", key, err)
+	}
+	if secret {
+		r.mu.Lock()
+		r.secrets[key] = struct{}{}
+		r.mu.Unlock()
+	}
+	return out, nil
+}
+
+// expandDepth expands the placeholders of s, it reports whether any of them is a secret.
+func (r *resolver) expandDepth(input map[string]interface{}, s string, depth int) (string, bool, error) {
+	var (
+		err    error
+		secret bool
+	)
+	out := placeholder.ReplaceAllStringFunc(s, func(m string) string {
+		if err != nil {
+			return m
+		}
+		v, isSecret, lerr := r.lookup(input, strings.TrimSpace(m[2:len(m)-1]), depth)
+		if lerr != nil {
+			err = fmt.Errorf("%s: %w", m, lerr)
+			return m
+		}
+		if isSecret {
+			redact.Mark(v)
+			secret = true
+		}
+		return v
+	})
+	return out, secret, err
+}
+
+// lookup returns the value of the reference and whether it is a secret.
+func (r *resolver) lookup(input map[string]interface{}, ref string, depth int) (string, bool, error) {
+	scheme, rest, _ := strings.Cut(ref, ":")
+	name, def, hasDef := strings.Cut(rest, ":")
+	switch scheme {
+	case "env":
+		if v, ok := os.LookupEnv(name); ok {
+			return v, false, nil
+		}
+		if hasDef {
+			return def, false, nil
+		}
+		return "", false, fmt.Errorf("environment variable %s is not set", name)
+	case "file":
+		b, err := os.ReadFile(name)
+		if err != nil {
+			if hasDef && errors.Is(err, os.ErrNotExist) {
+				return def, false, nil
+			}
+			return "", false, err
+		}
+		return strings.TrimSpace(string(b)), true, nil
+	case "secret":
+		providerName, key, ok := strings.Cut(name, "/")
+		if !ok {
+			return "", false, fmt.Errorf("invalid secret %q, expected provider/key", name)
+		}
+		p, ok := secretProvider(providerName)
+		if !ok {
+			return "", false, fmt.Errorf("secret provider %q is not registered", providerName)
+		}
+		v, err := p.Secret(key)
+		if err != nil {
+			if hasDef && errors.Is(err, ErrSecretNotFound) {
+				return def, false, nil
+			}
+			return "", false, err
+		}
+		return v, true, nil
+	}
+
+	// the other config key, its placeholders may not be expanded yet
+	key, def, hasDef := strings.Cut(ref, ":")
+	v, ok := readValue(input, key)
+	if !ok {
+		if hasDef {
+			return def, false, nil
+		}
+		return "", false, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Sprint(v), false, nil
+	}
+	if depth >= maxDepth {
+		return "", false, fmt.Errorf("too many nested placeholders, a cycle?")
+	}
+	out, secret, err := r.expandDepth(input, s, depth+1)
+	// the key may be resolved from a secret already
+	return out, secret || r.isSecret(key), err
+}
+
+func readValue(values map[string]interface{}, path string) (interface{}, bool) {
+	keys := strings.Split(path, ".")
+	var next interface{} = values
+	for _, k := range keys {
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if next, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return next, true
+}
+
+// isSecret reports whether the value of key is resolved from a secret.
+func (r *resolver) isSecret(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.secrets[key]
+	return ok
+}
+
+// Dump returns the effective default config as JSON, the values resolved from the secrets are redacted.
+func Dump() ([]byte, error) {
+	c, ok := DefaultConfig.(*Config)
+	if !ok {
+		return nil, errors.New("config: the secrets are only tracked by Init")
+	}
+	values := make(map[string]interface{})
+	if err := c.Scan(&values); err != nil {
+		return nil, err
+	}
+	c.redact("", values)
+	b, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	// the secrets copied by the key placeholders
+	return []byte(redact.String(string(b))), nil
+}
+
+func (c *Config) redact(path string, values map[string]interface{}) {
+	for k, v := range values {
+		key := k
+		if path != "" {
+			key = path + "." + k
+		}
+		switch vt := v.(type) {
+		case map[string]interface{}:
+			c.redact(key, vt)
+		case []interface{}:
+			for i, item := range vt {
+				itemKey := fmt.Sprintf("%s[%d]", key, i)
+				if m, ok := item.(map[string]interface{}); ok {
+					c.redact(itemKey, m)
+				} else if c.resolver.isSecret(itemKey) {
+					vt[i] = redact.Mask
+				}
+			}
+		default:
+			if c.resolver.isSecret(key) {
+				values[k] = redact.Mask
+			}
+		}
+	}
+}
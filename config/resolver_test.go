@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nextmicro/next/config"
+	"github.com/nextmicro/next/pkg/redact"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver(t *testing.T) {
+	dir := t.TempDir()
+	secrets := filepath.Join(dir, "secrets")
+	assert.NoError(t, os.Mkdir(secrets, 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(secrets, "nacos-password"), []byte("nacos-s3cr3t\n"), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "kafka-password"), []byte("kafka-s3cr3t"), 0o600))
+	config.RegisterSecretProvider("testdir", config.NewFileSecretProvider(secrets))
+	t.Setenv("RESOLVER_DB_HOST", "db.local")
+
+	path := filepath.Join(dir, "app.yaml")
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write(`
+name: ${env:RESOLVER_APP_NAME:resolver}
+nacos:
+  username: nacos
+  password: ${secret:testdir/nacos-password}
+broker:
+  addrs:
+    - ${env:RESOLVER_DB_HOST}:9092
+kafka:
+  password: ${file:` + filepath.Join(dir, "kafka-password") + `}
+  token: ${secret:testdir/missing:none}
+database:
+  dsn: root:${nacos.password}@tcp(${env:RESOLVER_DB_HOST}:3306)/app
+`)
+
+	c, err := config.Init(path)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	app := config.ApplicationConfig()
+	assert.Equal(t, "resolver", app.GetName())
+	assert.Equal(t, "nacos-s3cr3t", app.GetNacos().GetPassword())
+	assert.Equal(t, []string{"db.local:9092"}, app.GetBroker().GetAddrs())
+	for key, expected := range map[string]string{
+		"kafka.password": "kafka-s3cr3t",
+		"kafka.token":    "none",
+		"database.dsn":   "root:nacos-s3cr3t@tcp(db.local:3306)/app",
+	} {
+		v, err := c.Value(key).String()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+
+	b, err := config.Dump()
+	assert.NoError(t, err)
+	var dump struct {
+		Nacos    map[string]interface{} `json:"nacos"`
+		Kafka    map[string]interface{} `json:"kafka"`
+		Database map[string]interface{} `json:"database"`
+	}
+	assert.NoError(t, json.Unmarshal(b, &dump))
+	assert.Equal(t, redact.Mask, dump.Nacos["password"])
+	assert.Equal(t, "nacos", dump.Nacos["username"])
+	assert.Equal(t, redact.Mask, dump.Kafka["password"])
+	// resolved from the secret of nacos.password
+	assert.Equal(t, redact.Mask, dump.Database["dsn"])
+	assert.Equal(t, "password: "+redact.Mask, redact.String("password: kafka-s3cr3t"))
+
+	write(`
+nacos:
+  password: ${secret:testdir/missing}
+`)
+	_, err = config.Init(path)
+	assert.ErrorContains(t, err, "resolve nacos.password: ${secret:testdir/missing}: secret not found")
+
+	write(`
+name: ${env:RESOLVER_MISSING}
+`)
+	_, err = config.Init(path)
+	assert.ErrorContains(t, err, "environment variable RESOLVER_MISSING is not set")
+}
+
+func TestResolver_secretReference(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("ref-s3cr3t"), 0o600))
+	path := filepath.Join(dir, "app.yaml")
+	// the referring keys sort both before and after the secret key
+	assert.NoError(t, os.WriteFile(path, []byte(`
+a:
+  dsn: root:${m.password}@tcp(a.local)/app
+m:
+  password: ${file:`+filepath.Join(dir, "password")+`}
+z:
+  dsn: root:${m.password}@tcp(z.local)/app
+`), 0o600))
+
+	for i := 0; i < 50; i++ {
+		c, err := config.Init(path)
+		assert.NoError(t, err)
+		b, err := config.Dump()
+		assert.NoError(t, err)
+		c.Close()
+
+		var dump map[string]map[string]interface{}
+		assert.NoError(t, json.Unmarshal(b, &dump))
+		assert.Equal(t, redact.Mask, dump["a"]["dsn"])
+		assert.Equal(t, redact.Mask, dump["m"]["password"])
+		assert.Equal(t, redact.Mask, dump["z"]["dsn"])
+	}
+}
@@ -0,0 +1,246 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	v1 "github.com/nextmicro/next/api/config/v1"
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Violation is an invalid value of the config, Path is the config key, e.g. server.http.middlewares[0].options.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	return v.Path + ": " + v.Message
+}
+
+// ValidationError reports all the violations of the config.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid config, %d violation(s):", len(e.Violations))
+	for _, v := range e.Violations {
+		b.WriteString("\n\t")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
+
+// Validate validates the framework config, it returns a *ValidationError reporting all the violations.
+func Validate(c *v1.Next) error {
+	v := &validator{}
+	v.server(c.GetServer())
+	v.logger(c.GetLogger())
+	v.registry(c.GetRegistry())
+	v.telemetry(c.GetTelemetry())
+	v.nacos(c.GetNacos())
+	v.broker(c.GetBroker())
+	if len(v.violations) > 0 {
+		return &ValidationError{Violations: v.violations}
+	}
+	return nil
+}
+
+// ValidateFile loads the config files of filename as Init does and validates them, without initializing
+// the default config, e.g. checking the config in CI. The Nacos sources are not loaded.
+func ValidateFile(filename string) error {
+	cc := &Config{
+		path:     filepath.Dir(filename),
+		filename: filename,
+		resolver: newResolver(),
+	}
+	c := cc.newConfig(cc.buildFileSource()...)
+	defer c.Close()
+	if err := c.Load(); err != nil {
+		return fmt.Errorf("failed to load config filename: %s, error: %s", filename, err)
+	}
+
+	out := &v1.Next{}
+	if err := c.Scan(out); err != nil {
+		return fmt.Errorf("failed to scan config: %s", err)
+	}
+	return Validate(out)
+}
+
+var (
+	networks = []string{"", "tcp", "tcp4", "tcp6", "unix"}
+	// the levels of the logger, see logger.ParseLevel
+	levels     = []string{"", "debug", "info", "warn", "error", "fatal"}
+	rotations  = []string{"", "daily", "size", "hour"}
+	registries = []string{"", "memory", "file", "consul", "etcd", "nacos"}
+	exporters  = []string{"otlphttp", "otlpgrpc", "stdout", "zipkin", "file", "noop"}
+	brokers    = []string{"", "memory", "kafka"}
+)
+
+type validator struct {
+	violations []Violation
+}
+
+func (v *validator) add(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) oneOf(path, value string, values []string) {
+	for _, s := range values {
+		if value == s {
+			return
+		}
+	}
+	v.add(path, "unsupported %q, must be one of [%s]", value, strings.Join(values, ", "))
+}
+
+func (v *validator) duration(path string, d *durationpb.Duration) {
+	if d == nil {
+		return
+	}
+	if err := d.CheckValid(); err != nil {
+		v.add(path, "%v", err)
+	} else if d.AsDuration() < 0 {
+		v.add(path, "must not be negative, got %s", d.AsDuration())
+	}
+}
+
+func (v *validator) nonNegative(path string, n int64) {
+	if n < 0 {
+		v.add(path, "must not be negative, got %d", n)
+	}
+}
+
+// hostPort checks the address is host:port, the host may be empty to listen on all the interfaces.
+func (v *validator) hostPort(path, addr string, hostRequired bool) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		v.add(path, "invalid address %q: %v", addr, err)
+		return
+	}
+	if hostRequired && host == "" {
+		v.add(path, "invalid address %q: missing host", addr)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 0 || p > 65535 {
+		v.add(path, "invalid address %q: bad port %q", addr, port)
+	}
+}
+
+func (v *validator) listen(path, network, addr string) {
+	v.oneOf(path+".network", network, networks)
+	if addr != "" && network != "unix" {
+		v.hostPort(path+".addr", addr, false)
+	}
+}
+
+func (v *validator) middlewares(path, kind string, ms []*v1.Middleware, strict bool) {
+	for i, m := range ms {
+		if err := chain.Validate(kind, m, chain.Strict(strict)); err != nil {
+			v.add(fmt.Sprintf("%s.middlewares[%d]", path, i), "%v", err)
+		}
+	}
+}
+
+func (v *validator) server(c *v1.Server) {
+	if c.GetHttp() != nil {
+		v.listen("server.http", c.GetHttp().GetNetwork(), c.GetHttp().GetAddr())
+		v.duration("server.http.timeout", c.GetHttp().GetTimeout())
+		v.middlewares("server.http", "http.server", c.GetHttp().GetMiddlewares(), c.GetHttp().GetStrictMiddlewares())
+	}
+	if c.GetGrpc() != nil {
+		v.listen("server.grpc", c.GetGrpc().GetNetwork(), c.GetGrpc().GetAddr())
+		v.duration("server.grpc.timeout", c.GetGrpc().GetTimeout())
+		v.middlewares("server.grpc", "grpc.server", c.GetGrpc().GetMiddlewares(), c.GetGrpc().GetStrictMiddlewares())
+	}
+	if c.GetAdmin() != nil && !c.GetAdmin().GetDisable() {
+		v.listen("server.admin", c.GetAdmin().GetNetwork(), c.GetAdmin().GetAddr())
+	}
+	v.duration("server.drain.pre_stop_delay", c.GetDrain().GetPreStopDelay())
+}
+
+func (v *validator) logger(c *v1.Logger) {
+	if c == nil {
+		return
+	}
+	v.oneOf("logger.level", strings.ToLower(c.GetLevel()), levels)
+	v.oneOf("logger.rotation", c.GetRotation(), rotations)
+	v.nonNegative("logger.keep_days", int64(c.GetKeepDays()))
+	v.nonNegative("logger.keep_hours", int64(c.GetKeepHours()))
+	v.nonNegative("logger.max_backups", int64(c.GetMaxBackups()))
+	v.nonNegative("logger.max_size", int64(c.GetMaxSize()))
+}
+
+func (v *validator) registry(c *v1.Registry) {
+	if c == nil {
+		return
+	}
+	v.oneOf("registry.name", c.GetName(), registries)
+	switch c.GetName() {
+	case "consul", "etcd", "nacos":
+		for _, addr := range strings.Split(c.GetAddrs(), ",") {
+			addr = strings.TrimSpace(addr)
+			if i := strings.Index(addr, "://"); i >= 0 {
+				addr = addr[i+3:]
+			}
+			// the default port of the registry is added if missing
+			if addr != "" && strings.Contains(addr, ":") {
+				v.hostPort("registry.addrs", addr, true)
+			}
+		}
+	}
+	v.duration("registry.timeout", c.GetTimeout())
+	v.duration("registry.ttl", c.GetTtl())
+	v.duration("registry.health_check_interval", c.GetHealthCheckInterval())
+	v.duration("registry.deregister_critical_after", c.GetDeregisterCriticalAfter())
+	if c.GetWeight() < 0 {
+		v.add("registry.weight", "must not be negative, got %v", c.GetWeight())
+	}
+}
+
+func (v *validator) telemetry(c *v1.Telemetry) {
+	if c == nil || c.GetDisable() {
+		return
+	}
+	v.oneOf("telemetry.exporter", c.GetExporter(), exporters)
+	switch c.GetExporter() {
+	case "otlphttp", "otlpgrpc", "zipkin", "file":
+		if c.GetEndpoint() == "" {
+			v.add("telemetry.endpoint", "required by the %s exporter", c.GetExporter())
+		}
+	}
+	if c.GetSampler() < 0 || c.GetSampler() > 1 {
+		v.add("telemetry.sampler", "must be in [0, 1], got %v", c.GetSampler())
+	}
+}
+
+func (v *validator) nacos(c *v1.Nacos) {
+	if c == nil {
+		return
+	}
+	if c.GetDataId() != "" && len(c.GetAddress()) == 0 {
+		v.add("nacos.address", "required with nacos.data_id")
+	}
+	for i, addr := range c.GetAddress() {
+		v.hostPort(fmt.Sprintf("nacos.address[%d]", i), addr, true)
+	}
+	v.duration("nacos.timeout", c.GetTimeout())
+}
+
+func (v *validator) broker(c *v1.Broker) {
+	if c == nil || c.GetDisable() {
+		return
+	}
+	v.oneOf("broker.name", c.GetName(), brokers)
+	if c.GetName() == "kafka" && len(c.GetAddrs()) == 0 {
+		v.add("broker.addrs", "required by the %s broker", c.GetName())
+	}
+	for i, addr := range c.GetAddrs() {
+		v.hostPort(fmt.Sprintf("broker.addrs[%d]", i), addr, true)
+	}
+}
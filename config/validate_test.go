@@ -0,0 +1,106 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	v1 "github.com/nextmicro/next/api/config/v1"
+	ratelimitv1 "github.com/nextmicro/next/api/middleware/ratelimit/v1"
+	"github.com/nextmicro/next/config"
+	"github.com/nextmicro/next/middleware"
+	_ "github.com/nextmicro/next/middleware/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	middleware.Register("server.validate", func(*v1.Middleware) (kmiddleware.Middleware, error) {
+		return nil, nil
+	})
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, config.Validate(&v1.Next{}))
+
+	err := config.Validate(&v1.Next{
+		Server: &v1.Server{
+			Http: &v1.HTTPServer{
+				Addr: "0.0.0.0",
+				Middlewares: []*v1.Middleware{
+					{Name: "validate", Options: &anypb.Any{TypeUrl: "type.googleapis.com/unknown.Options"}},
+					{Name: "unknown"},
+				},
+				StrictMiddlewares: true,
+			},
+			Grpc: &v1.GRPCServer{
+				Addr:        ":9000",
+				Middlewares: []*v1.Middleware{{Name: "unknown", Selectors: []string{"helloworld.v1.Greeter/*"}}},
+			},
+		},
+		Registry:  &v1.Registry{Name: "zookeeper"},
+		Telemetry: &v1.Telemetry{Exporter: "otlpgrpc", Sampler: 1.5},
+		Nacos:     &v1.Nacos{Address: []string{"127.0.0.1"}},
+		Broker:    &v1.Broker{Name: "kafka"},
+		Logger:    &v1.Logger{Level: "INFO"},
+	})
+
+	var verr *config.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	paths := make([]string, 0, len(verr.Violations))
+	for _, v := range verr.Violations {
+		paths = append(paths, v.Path)
+	}
+	assert.Equal(t, []string{
+		"server.http.addr",
+		"server.http.middlewares[0]",
+		"server.http.middlewares[1]",
+		"server.grpc.middlewares[0]",
+		"registry.name",
+		"telemetry.endpoint",
+		"telemetry.sampler",
+		"nacos.address[0]",
+		"broker.addrs",
+	}, paths)
+	assert.ErrorContains(t, err, `registry.name: unsupported "zookeeper"`)
+	assert.ErrorContains(t, err, "server.http.middlewares[0]: invalid options")
+}
+
+func TestValidateMiddlewareOptions(t *testing.T) {
+	limit, err := anypb.New(&ratelimitv1.RateLimit{Rules: []*ratelimitv1.Rule{{Operation: "/helloworld.v1.Greeter/*"}}})
+	assert.NoError(t, err)
+
+	err = config.Validate(&v1.Next{
+		Server: &v1.Server{
+			Http: &v1.HTTPServer{
+				Middlewares: []*v1.Middleware{{Name: "logging"}, {Name: "ratelimit", Options: limit}},
+			},
+		},
+	})
+	assert.EqualError(t, err, "invalid config, 1 violation(s):\n\tserver.http.middlewares[1]: invalid options: ratelimit: rules[0]: quota must be positive, got 0")
+}
+
+func TestValidateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dev.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+broker:
+  name: kafka
+  addrs:
+    - 127.0.0.1:9092
+telemetry:
+  exporter: stdout
+  sampler: 0.5
+`), 0o600))
+	assert.NoError(t, config.ValidateFile(path))
+
+	assert.NoError(t, os.WriteFile(path, []byte(`
+broker:
+  name: kafka
+`), 0o600))
+	assert.EqualError(t, config.ValidateFile(path), "invalid config, 1 violation(s):\n\tbroker.addrs: required by the kafka broker")
+
+	_, err := config.Init(path)
+	assert.ErrorContains(t, err, "broker.addrs: required by the kafka broker")
+}
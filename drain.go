@@ -0,0 +1,101 @@
+package next
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/nextmicro/logger"
+	"github.com/nextmicro/next/config"
+)
+
+// Drainer is implemented by the servers which stop taking new traffic before they stop,
+// e.g. the gRPC server sets the health status NOT_SERVING.
+type Drainer interface {
+	Drain(ctx context.Context) error
+}
+
+// drainRegistrar records the registered instance so that the drain deregisters it before the servers stop,
+// the instance is deregistered only once.
+type drainRegistrar struct {
+	registry.Registrar
+
+	mu       sync.Mutex
+	instance *registry.ServiceInstance
+}
+
+func (r *drainRegistrar) Register(ctx context.Context, service *registry.ServiceInstance) error {
+	if err := r.Registrar.Register(ctx, service); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.instance = service
+	r.mu.Unlock()
+	return nil
+}
+
+func (r *drainRegistrar) Deregister(ctx context.Context, service *registry.ServiceInstance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.instance == nil {
+		return nil
+	}
+	if err := r.Registrar.Deregister(ctx, service); err != nil {
+		return err
+	}
+	r.instance = nil
+	return nil
+}
+
+// deregister deregisters the registered instance, if any.
+func (r *drainRegistrar) deregister(ctx context.Context) error {
+	r.mu.Lock()
+	service := r.instance
+	r.mu.Unlock()
+	if service == nil {
+		return nil
+	}
+	return r.Deregister(ctx, service)
+}
+
+// drain runs before the servers stop: /readyz fails, the servers are drained,
+// the instance is deregistered and then waits for `server.drain.pre_stop_delay`,
+// so that the clients with stale discovery caches stop sending traffic.
+func (app *Next) drain(ctx context.Context) error {
+	app.ready.Store(false)
+
+	c := config.ApplicationConfig().GetServer().GetDrain()
+	if c.GetDisable() {
+		return nil
+	}
+
+	for _, srv := range app.opt.Servers {
+		if d, ok := srv.(Drainer); ok {
+			if err := d.Drain(ctx); err != nil {
+				logger.Warnf("drain server error: %v", err)
+			}
+		}
+	}
+
+	if app.registrar != nil {
+		rctx, cancel := context.WithTimeout(ctx, app.opt.RegistrarTimeout)
+		err := app.registrar.deregister(rctx)
+		cancel()
+		if err != nil {
+			logger.Warnf("drain deregister error: %v", err)
+		}
+	}
+
+	if delay := c.GetPreStopDelay().AsDuration(); delay > 0 {
+		logger.Infof("draining, waiting %s before stopping servers", delay)
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package next
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+)
+
+type fakeRegistrar struct {
+	deregistered int
+}
+
+func (r *fakeRegistrar) Register(context.Context, *registry.ServiceInstance) error { return nil }
+
+func (r *fakeRegistrar) Deregister(context.Context, *registry.ServiceInstance) error {
+	r.deregistered++
+	return nil
+}
+
+type fakeServer struct {
+	transport.Server
+	drained bool
+}
+
+func (s *fakeServer) Drain(context.Context) error {
+	s.drained = true
+	return nil
+}
+
+func TestDrain(t *testing.T) {
+	fr := &fakeRegistrar{}
+	srv := &fakeServer{}
+	app := &Next{
+		opt:       Options{Servers: []transport.Server{srv}},
+		registrar: &drainRegistrar{Registrar: fr},
+	}
+	app.ready.Store(true)
+
+	si := &registry.ServiceInstance{ID: "1", Name: "greeter"}
+	if err := app.registrar.Register(context.Background(), si); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if app.ready.Load() {
+		t.Error("expect not ready after drain")
+	}
+	if !srv.drained {
+		t.Error("expect server drained")
+	}
+	if fr.deregistered != 1 {
+		t.Errorf("expect deregistered once, got %d", fr.deregistered)
+	}
+
+	// the deregister after drain is skipped
+	if err := app.registrar.Deregister(context.Background(), si); err != nil {
+		t.Fatal(err)
+	}
+	if fr.deregistered != 1 {
+		t.Errorf("expect deregistered once, got %d", fr.deregistered)
+	}
+}
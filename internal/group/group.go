@@ -45,6 +45,14 @@ func (g *Group) Get(key string) interface{} {
 	return v
 }
 
+// New creates an object by the new function, it is not cached by the group.
+func (g *Group) New() interface{} {
+	g.RLock()
+	new := g.new
+	g.RUnlock()
+	return new()
+}
+
 // Reset resets the new function and deletes all existing objects.
 func (g *Group) Reset(new func() interface{}) {
 	if new == nil {
@@ -62,3 +70,14 @@ func (g *Group) Clear() {
 	g.vals = make(map[string]interface{})
 	g.Unlock()
 }
+
+// Wrap returns a new group creating the objects by the new function of g wrapped by fn,
+// the objects of g are not shared.
+func (g *Group) Wrap(fn func(interface{}) interface{}) *Group {
+	return NewGroup(func() interface{} {
+		g.RLock()
+		new := g.new
+		g.RUnlock()
+		return fn(new())
+	})
+}
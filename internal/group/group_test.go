@@ -77,3 +77,18 @@ func TestGroupClear(t *testing.T) {
 		t.Errorf("expect length 0, actual %v", length)
 	}
 }
+
+func TestGroupWrap(t *testing.T) {
+	g := NewGroup(func() interface{} {
+		return 1
+	})
+	w := g.Wrap(func(v interface{}) interface{} {
+		return v.(int) + 1
+	})
+	if v := w.Get("key"); !reflect.DeepEqual(v, 2) {
+		t.Errorf("expect 2, actual %v", v)
+	}
+	if len(g.vals) != 0 {
+		t.Errorf("expect length 0, actual %v", len(g.vals))
+	}
+}
@@ -3,8 +3,10 @@ package matcher
 import (
 	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/go-kratos/kratos/v2/middleware"
+	chain "github.com/nextmicro/next/middleware"
 )
 
 // Matcher is a middleware matcher.
@@ -14,24 +16,41 @@ type Matcher interface {
 	Match(operation string) []middleware.Middleware
 }
 
+// StreamMatcher is a stream middleware matcher.
+type StreamMatcher interface {
+	Use(ms ...chain.StreamMiddleware)
+	Add(selector string, ms ...chain.StreamMiddleware)
+	Match(operation string) []chain.StreamMiddleware
+}
+
 // New new a middleware matcher.
 func New() Matcher {
-	return &matcher{
-		matchs: make(map[string][]middleware.Middleware),
-	}
+	return newMatcher[middleware.Middleware]()
+}
+
+// NewStream new a stream middleware matcher.
+func NewStream() StreamMatcher {
+	return newMatcher[chain.StreamMiddleware]()
 }
 
-type matcher struct {
+type matcher[T any] struct {
 	prefix   []string
-	defaults []middleware.Middleware
-	matchs   map[string][]middleware.Middleware
+	defaults atomic.Pointer[[]T]
+	matchs   map[string][]T
 }
 
-func (m *matcher) Use(ms ...middleware.Middleware) {
-	m.defaults = ms
+func newMatcher[T any]() *matcher[T] {
+	return &matcher[T]{
+		matchs: make(map[string][]T),
+	}
+}
+
+// Use replaces the default middlewares, it is safe to call while matching.
+func (m *matcher[T]) Use(ms ...T) {
+	m.defaults.Store(&ms)
 }
 
-func (m *matcher) Add(selector string, ms ...middleware.Middleware) {
+func (m *matcher[T]) Add(selector string, ms ...T) {
 	if strings.HasSuffix(selector, "*") {
 		selector = strings.TrimSuffix(selector, "*")
 		m.prefix = append(m.prefix, selector)
@@ -45,10 +64,14 @@ func (m *matcher) Add(selector string, ms ...middleware.Middleware) {
 	m.matchs[selector] = ms
 }
 
-func (m *matcher) Match(operation string) []middleware.Middleware {
-	ms := make([]middleware.Middleware, 0, len(m.defaults))
-	if len(m.defaults) > 0 {
-		ms = append(ms, m.defaults...)
+func (m *matcher[T]) Match(operation string) []T {
+	var defaults []T
+	if p := m.defaults.Load(); p != nil {
+		defaults = *p
+	}
+	ms := make([]T, 0, len(defaults))
+	if len(defaults) > 0 {
+		ms = append(ms, defaults...)
 	}
 	if next, ok := m.matchs[operation]; ok {
 		return append(ms, next...)
@@ -0,0 +1,214 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/nextmicro/logger"
+)
+
+// ErrKeyNotFound is the token kid not found in the JWKS.
+var ErrKeyNotFound = errors.New("jwt: key not found")
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+type webKey struct {
+	alg string
+	key interface{} // []byte, *rsa.PublicKey or *ecdsa.PublicKey
+}
+
+// JWKS is the key set loaded from a local JWKS file, the file is reloaded periodically until closed.
+type JWKS struct {
+	file string
+	done chan struct{}
+	once sync.Once
+
+	mu      sync.RWMutex
+	keys    map[string]webKey
+	modTime time.Time
+}
+
+// LoadJWKS loads the JWKS file and reloads it every interval when it is modified,
+// a zero interval disables the reload.
+func LoadJWKS(file string, interval time.Duration) (*JWKS, error) {
+	s := &JWKS{file: file, done: make(chan struct{})}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if interval > 0 {
+		go s.reload(interval)
+	}
+	return s, nil
+}
+
+func (s *JWKS) reload(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		if err := s.load(); err != nil {
+			// keep the keys loaded last time
+			log.Warnf("jwt: reload jwks [%s] error: %v", s.file, err)
+		}
+	}
+}
+
+// Close stops reloading the file, the keys loaded are kept.
+func (s *JWKS) Close() error {
+	s.once.Do(func() { close(s.done) })
+	return nil
+}
+
+func (s *JWKS) load() error {
+	info, err := os.Stat(s.file)
+	if err != nil {
+		return err
+	}
+	s.mu.RLock()
+	modTime := s.modTime
+	s.mu.RUnlock()
+	if info.ModTime().Equal(modTime) {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.file)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.modTime = info.ModTime()
+	s.mu.Unlock()
+	log.Infof("jwt: load jwks [%s] keys: %d", s.file, len(keys))
+	return nil
+}
+
+// Key returns the key of kid for the alg, an empty kid is allowed when there is only one key.
+func (s *JWKS) Key(kid, alg string) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	k, ok := s.keys[kid]
+	if !ok && kid == "" && len(s.keys) == 1 {
+		for _, v := range s.keys {
+			k, ok = v, true
+		}
+	}
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	if k.alg != "" && k.alg != alg {
+		return nil, fmt.Errorf("jwt: key %q is not for %s", kid, alg)
+	}
+	return k.key, nil
+}
+
+func parseJWKS(data []byte) (map[string]webKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("jwt: invalid jwks: %v", err)
+	}
+
+	keys := make(map[string]webKey, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("jwt: jwks keys[%d]: %v", i, err)
+		}
+		keys[k.Kid] = webKey{alg: k.Alg, key: key}
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) parse() (interface{}, error) {
+	switch k.Kty {
+	case "oct":
+		return decodeSegment(k.K)
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported crv %q", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on the curve")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported kty %q", k.Kty)
+	}
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := decodeSegment(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, errors.New("empty integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,411 @@
+package jwt
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/jwt/v1"
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	chain.RegisterCloser("server.auth.jwt", injectionServer)
+	chain.Register("client.auth.jwt", injectionClient)
+	chain.RegisterStreamSafe("server.auth.jwt", "client.auth.jwt")
+}
+
+const (
+	// bearerWord the bearer key word for authorization
+	bearerWord = "Bearer"
+
+	// authorizationKey holds the key used to store the JWT Token in the request header.
+	authorizationKey = "Authorization"
+
+	reason = "UNAUTHORIZED"
+
+	defaultReloadInterval = time.Minute
+)
+
+var (
+	ErrMissingToken       = errors.Unauthorized(reason, "JWT token is missing")
+	ErrTokenInvalid       = errors.Unauthorized(reason, "Token is invalid")
+	ErrTokenExpired       = errors.Unauthorized(reason, "JWT token has expired")
+	ErrMissingExpiration  = errors.Unauthorized(reason, "JWT token expiration is missing")
+	ErrTokenNotValidYet   = errors.Unauthorized(reason, "JWT token is not valid yet")
+	ErrTokenIssuer        = errors.Unauthorized(reason, "JWT token issuer is invalid")
+	ErrTokenAudience      = errors.Unauthorized(reason, "JWT token audience is invalid")
+	ErrUnsupportedMethod  = errors.Unauthorized(reason, "Wrong signing method")
+	ErrWrongContext       = errors.Unauthorized(reason, "Wrong context for middleware")
+	ErrMissingClientToken = errors.Unauthorized(reason, "JWT token of the client is missing")
+)
+
+// algorithms are the supported signing algorithms.
+var algorithms = []string{
+	"HS256", "HS384", "HS512",
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+}
+
+type Option func(o *Options)
+
+type Options struct {
+	header     string
+	algorithms []string
+	secret     []byte
+	jwks       *JWKS
+	keyFunc    jwt.Keyfunc
+	issuer     string
+	audience   []string
+	clockSkew  time.Duration
+	requireExp bool
+	allows     []string
+	token      string
+	now        func() time.Time
+}
+
+// WithHeader with the header of the token, default: Authorization.
+func WithHeader(header string) Option {
+	return func(o *Options) {
+		o.header = header
+	}
+}
+
+// WithAlgorithms with the allowed signing algorithms, default: all supported.
+func WithAlgorithms(algs ...string) Option {
+	return func(o *Options) {
+		o.algorithms = algs
+	}
+}
+
+// WithSecret with the HMAC secret of HS algorithms.
+func WithSecret(secret []byte) Option {
+	return func(o *Options) {
+		o.secret = secret
+	}
+}
+
+// WithJWKS with the key set selected by the token kid.
+func WithJWKS(jwks *JWKS) Option {
+	return func(o *Options) {
+		o.jwks = jwks
+	}
+}
+
+// WithKeyFunc with the key func, it takes precedence over the secret and JWKS.
+func WithKeyFunc(f jwt.Keyfunc) Option {
+	return func(o *Options) {
+		o.keyFunc = f
+	}
+}
+
+// WithIssuer with the expected issuer.
+func WithIssuer(issuer string) Option {
+	return func(o *Options) {
+		o.issuer = issuer
+	}
+}
+
+// WithAudience with the expected audience, the token must contain one of them.
+func WithAudience(audience ...string) Option {
+	return func(o *Options) {
+		o.audience = audience
+	}
+}
+
+// WithClockSkew with the leeway of exp, nbf and iat.
+func WithClockSkew(skew time.Duration) Option {
+	return func(o *Options) {
+		o.clockSkew = skew
+	}
+}
+
+// WithExpirationRequired rejects the tokens without exp, default: true.
+func WithExpirationRequired(required bool) Option {
+	return func(o *Options) {
+		o.requireExp = required
+	}
+}
+
+// WithAllowOperations with the glob patterns of the operations skipping auth.
+func WithAllowOperations(patterns ...string) Option {
+	return func(o *Options) {
+		o.allows = patterns
+	}
+}
+
+// WithToken with the client token sent when there is no token of the incoming request.
+func WithToken(token string) Option {
+	return func(o *Options) {
+		o.token = token
+	}
+}
+
+type authKey struct{}
+
+type authInfo struct {
+	token  string
+	claims jwt.MapClaims
+}
+
+// NewContext put the token and claims into context.
+func NewContext(ctx context.Context, token string, claims jwt.MapClaims) context.Context {
+	return context.WithValue(ctx, authKey{}, authInfo{token: token, claims: claims})
+}
+
+// FromContext extract the claims from context.
+func FromContext(ctx context.Context) (jwt.MapClaims, bool) {
+	info, ok := ctx.Value(authKey{}).(authInfo)
+	return info.claims, ok
+}
+
+// TokenFromContext extract the raw token from context.
+func TokenFromContext(ctx context.Context) (string, bool) {
+	info, ok := ctx.Value(authKey{}).(authInfo)
+	return info.token, ok && info.token != ""
+}
+
+func parseConfig(c *config.Middleware) (*v1.JWT, []Option, error) {
+	cfg := &v1.JWT{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	for _, pattern := range cfg.GetAllowOperations() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, nil, fmt.Errorf("jwt: allow_operations %q: %v", pattern, err)
+		}
+	}
+	for _, alg := range cfg.GetAlgorithms() {
+		if jwt.GetSigningMethod(alg) == nil {
+			return nil, nil, fmt.Errorf("jwt: unsupported algorithm %q", alg)
+		}
+	}
+
+	opts := []Option{
+		WithAlgorithms(cfg.GetAlgorithms()...),
+		WithIssuer(cfg.GetIssuer()),
+		WithAudience(cfg.GetAudience()...),
+		WithClockSkew(cfg.GetClockSkew().AsDuration()),
+		WithAllowOperations(cfg.GetAllowOperations()...),
+		WithToken(cfg.GetToken()),
+	}
+	if cfg.GetHeader() != "" {
+		opts = append(opts, WithHeader(cfg.GetHeader()))
+	}
+	if cfg.GetSecret() != "" {
+		opts = append(opts, WithSecret([]byte(cfg.GetSecret())))
+	}
+	return cfg, opts, nil
+}
+
+func injectionServer(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	cfg, opts, err := parseConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.GetSecret() == "" && cfg.GetJwksFile() == "" {
+		return nil, nil, fmt.Errorf("jwt: secret or jwks_file is required")
+	}
+	if cfg.GetJwksFile() == "" {
+		return Server(opts...), nil, nil
+	}
+
+	interval := defaultReloadInterval
+	if cfg.GetReloadInterval() != nil {
+		interval = cfg.GetReloadInterval().AsDuration()
+	}
+	jwks, err := LoadJWKS(cfg.GetJwksFile(), interval)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts = append(opts, WithJWKS(jwks))
+	return Server(opts...), jwks.Close, nil
+}
+
+func injectionClient(c *config.Middleware) (middleware.Middleware, error) {
+	_, opts, err := parseConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return Client(opts...), nil
+}
+
+func newOptions(opts ...Option) *Options {
+	options := &Options{
+		header:     authorizationKey,
+		algorithms: algorithms,
+		requireExp: true,
+		now:        time.Now,
+	}
+	for _, o := range opts {
+		o(options)
+	}
+	if len(options.algorithms) == 0 {
+		options.algorithms = algorithms
+	}
+	return options
+}
+
+// Server is a server auth middleware, it validates the JWT token and puts the claims into context.
+func Server(opts ...Option) middleware.Middleware {
+	o := newOptions(opts...)
+	keyFunc := o.keyFunc
+	if keyFunc == nil {
+		keyFunc = o.lookupKey
+	}
+	// the claims are validated with the clock skew, see validate
+	parser := jwt.NewParser(jwt.WithValidMethods(o.algorithms), jwt.WithoutClaimsValidation())
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, ErrWrongContext
+			}
+			if o.allowed(tr.Operation()) {
+				return handler(ctx, req)
+			}
+
+			auths := strings.SplitN(tr.RequestHeader().Get(o.header), " ", 2)
+			if len(auths) != 2 || !strings.EqualFold(auths[0], bearerWord) || auths[1] == "" {
+				return nil, ErrMissingToken
+			}
+
+			claims := jwt.MapClaims{}
+			token, err := parser.ParseWithClaims(auths[1], claims, keyFunc)
+			if err != nil {
+				// the errors of the key func are wrapped
+				if e := new(errors.Error); stderrors.As(err, &e) && e.GetReason() == reason {
+					return nil, e
+				}
+				return nil, ErrTokenInvalid
+			}
+			if !token.Valid {
+				return nil, ErrTokenInvalid
+			}
+			if err = o.validate(claims); err != nil {
+				return nil, err
+			}
+
+			ctx = NewContext(ctx, auths[1], claims)
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Client is a client auth middleware, it forwards the token of the incoming request,
+// or sends the configured token.
+func Client(opts ...Option) middleware.Middleware {
+	o := newOptions(opts...)
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return nil, ErrWrongContext
+			}
+			if tr.RequestHeader().Get(o.header) != "" {
+				return handler(ctx, req)
+			}
+
+			token, ok := TokenFromContext(ctx)
+			if !ok {
+				token = o.token
+			}
+			if token == "" {
+				if o.allowed(tr.Operation()) {
+					return handler(ctx, req)
+				}
+				return nil, ErrMissingClientToken
+			}
+
+			tr.RequestHeader().Set(o.header, bearerWord+" "+token)
+			return handler(ctx, req)
+		}
+	}
+}
+
+func (o *Options) allowed(operation string) bool {
+	for _, pattern := range o.allows {
+		if matched, _ := path.Match(pattern, operation); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupKey returns the secret of HS algorithms, or the JWKS key of the token kid.
+func (o *Options) lookupKey(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	kid, _ := token.Header["kid"].(string)
+	err := error(ErrUnsupportedMethod)
+	if o.jwks != nil {
+		var key interface{}
+		if key, err = o.jwks.Key(kid, alg); err == nil {
+			return key, nil
+		}
+	}
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && len(o.secret) > 0 {
+		return o.secret, nil
+	}
+	return nil, err
+}
+
+// validate validates the exp, nbf, iat with the clock skew, and the issuer and audience.
+func (o *Options) validate(claims jwt.MapClaims) error {
+	now := o.now()
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return ErrTokenInvalid
+	}
+	if exp == nil && o.requireExp {
+		return ErrMissingExpiration
+	}
+	if exp != nil && now.After(exp.Add(o.clockSkew)) {
+		return ErrTokenExpired
+	}
+	for _, get := range []func() (*jwt.NumericDate, error){claims.GetNotBefore, claims.GetIssuedAt} {
+		t, err := get()
+		if err != nil {
+			return ErrTokenInvalid
+		}
+		if t != nil && now.Add(o.clockSkew).Before(t.Time) {
+			return ErrTokenNotValidYet
+		}
+	}
+
+	if o.issuer != "" {
+		if iss, err := claims.GetIssuer(); err != nil || iss != o.issuer {
+			return ErrTokenIssuer
+		}
+	}
+	if len(o.audience) > 0 {
+		auds, err := claims.GetAudience()
+		if err != nil {
+			return ErrTokenAudience
+		}
+		for _, aud := range o.audience {
+			for _, a := range auds {
+				if a == aud {
+					return nil
+				}
+			}
+		}
+		return ErrTokenAudience
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range http.Header(hc) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type Transport struct {
+	transport.Transporter
+	operation string
+	header    headerCarrier
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindHTTP
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+func (tr *Transport) RequestHeader() transport.Header {
+	return tr.header
+}
+
+func newServerContext(operation, token string) context.Context {
+	header := headerCarrier{}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return transport.NewServerContext(context.Background(), &Transport{operation: operation, header: header})
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return s
+}
+
+func handler(ctx context.Context, _ interface{}) (interface{}, error) {
+	claims, _ := FromContext(ctx)
+	return claims, nil
+}
+
+func TestServer(t *testing.T) {
+	secret := []byte("secret")
+	now := time.Now()
+	h := Server(
+		WithSecret(secret),
+		WithIssuer("next"),
+		WithAudience("bff", "admin"),
+		WithClockSkew(time.Minute),
+		WithAllowOperations("/helloworld.Greeter/Public*"),
+	)(handler)
+
+	tests := []struct {
+		name   string
+		op     string
+		claims jwt.MapClaims
+		err    error
+	}{
+		{"valid", "/helloworld.Greeter/SayHello", jwt.MapClaims{"sub": "u1", "iss": "next", "aud": []string{"bff"}, "exp": now.Add(time.Hour).Unix()}, nil},
+		{"expired in skew", "/helloworld.Greeter/SayHello", jwt.MapClaims{"sub": "u1", "iss": "next", "aud": "admin", "exp": now.Add(-30 * time.Second).Unix()}, nil},
+		{"expired", "/helloworld.Greeter/SayHello", jwt.MapClaims{"iss": "next", "aud": "bff", "exp": now.Add(-2 * time.Minute).Unix()}, ErrTokenExpired},
+		{"not valid yet", "/helloworld.Greeter/SayHello", jwt.MapClaims{"iss": "next", "aud": "bff", "nbf": now.Add(2 * time.Minute).Unix(), "exp": now.Add(time.Hour).Unix()}, ErrTokenNotValidYet},
+		{"issuer", "/helloworld.Greeter/SayHello", jwt.MapClaims{"iss": "other", "aud": "bff", "exp": now.Add(time.Hour).Unix()}, ErrTokenIssuer},
+		{"audience", "/helloworld.Greeter/SayHello", jwt.MapClaims{"iss": "next", "aud": "web", "exp": now.Add(time.Hour).Unix()}, ErrTokenAudience},
+		{"missing exp", "/helloworld.Greeter/SayHello", jwt.MapClaims{"iss": "next", "aud": "bff"}, ErrMissingExpiration},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := sign(t, jwt.SigningMethodHS256, "", secret, tt.claims)
+			reply, err := h(newServerContext(tt.op, token), nil)
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err), "%v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "u1", reply.(jwt.MapClaims)["sub"])
+		})
+	}
+
+	_, err := h(newServerContext("/helloworld.Greeter/SayHello", ""), nil)
+	assert.True(t, errors.Is(err, ErrMissingToken))
+
+	_, err = h(newServerContext("/helloworld.Greeter/SayHello", sign(t, jwt.SigningMethodHS256, "", []byte("other"), jwt.MapClaims{})), nil)
+	assert.True(t, errors.Is(err, ErrTokenInvalid))
+
+	reply, err := h(newServerContext("/helloworld.Greeter/PublicHello", ""), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func writeJWKS(t *testing.T, file string, keys ...string) {
+	content := `{"keys":[`
+	for i, k := range keys {
+		if i > 0 {
+			content += ","
+		}
+		content += k
+	}
+	content += `]}`
+	assert.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+}
+
+func TestServerJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	rsaJWK := fmt.Sprintf(`{"kty":"RSA","kid":"rsa","alg":"RS256","n":"%s","e":"%s"}`,
+		encode(rsaKey.N.Bytes()), encode(big.NewInt(int64(rsaKey.E)).Bytes()))
+	ecJWK := fmt.Sprintf(`{"kty":"EC","kid":"ec","crv":"P-256","x":"%s","y":"%s"}`,
+		encode(ecKey.X.Bytes()), encode(ecKey.Y.Bytes()))
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, file, rsaJWK)
+	jwks, err := LoadJWKS(file, 10*time.Millisecond)
+	assert.NoError(t, err)
+
+	h := Server(WithJWKS(jwks), WithAlgorithms("RS256", "ES256"), WithExpirationRequired(false))(handler)
+	claims := jwt.MapClaims{"sub": "u1"}
+
+	_, err = h(newServerContext("/test", sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)), nil)
+	assert.NoError(t, err)
+
+	// the key of kid ec is not loaded yet
+	ecToken := sign(t, jwt.SigningMethodES256, "ec", ecKey, claims)
+	_, err = h(newServerContext("/test", ecToken), nil)
+	assert.True(t, errors.Is(err, ErrTokenInvalid))
+
+	// HS256 is not allowed
+	_, err = h(newServerContext("/test", sign(t, jwt.SigningMethodHS256, "rsa", []byte("secret"), claims)), nil)
+	assert.True(t, errors.Is(err, ErrTokenInvalid))
+
+	writeJWKS(t, file, rsaJWK, ecJWK)
+	// make sure the modification time changes
+	assert.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(time.Second)))
+	assert.Eventually(t, func() bool {
+		_, err = h(newServerContext("/test", ecToken), nil)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// the closed key set is not reloaded anymore
+	assert.NoError(t, jwks.Close())
+	time.Sleep(20 * time.Millisecond)
+	writeJWKS(t, file, ecJWK)
+	assert.NoError(t, os.Chtimes(file, time.Now(), time.Now().Add(2*time.Second)))
+	time.Sleep(50 * time.Millisecond)
+	_, err = h(newServerContext("/test", sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims)), nil)
+	assert.NoError(t, err)
+}
+
+func TestClient(t *testing.T) {
+	next := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		tr, _ := transport.FromClientContext(ctx)
+		return tr.RequestHeader().Get("Authorization"), nil
+	}
+	newClientContext := func(ctx context.Context) context.Context {
+		return transport.NewClientContext(ctx, &Transport{operation: "/test", header: headerCarrier{}})
+	}
+
+	h := Client(WithToken("static"))(next)
+	reply, err := h(newClientContext(context.Background()), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer static", reply)
+
+	// forward the token of the incoming request
+	ctx := NewContext(context.Background(), "incoming", jwt.MapClaims{})
+	reply, err = h(newClientContext(ctx), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer incoming", reply)
+
+	_, err = Client()(next)(newClientContext(context.Background()), nil)
+	assert.True(t, errors.Is(err, ErrMissingClientToken))
+}
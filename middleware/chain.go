@@ -0,0 +1,383 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	log "github.com/nextmicro/logger"
+	configv1 "github.com/nextmicro/next/api/config/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// BuildOption is the option of building the configured middlewares.
+type BuildOption func(*buildOptions)
+
+type buildOptions struct {
+	strict bool
+	reuse  any
+}
+
+// Strict fails the build on the middlewares not registered, with invalid options or selectors,
+// otherwise they are logged and skipped.
+func Strict(strict bool) BuildOption {
+	return func(o *buildOptions) {
+		o.strict = strict
+	}
+}
+
+// Reuse reuses the middleware instances of the previous build with the same name and options,
+// so that a reload keeps their state, e.g. the rate limiters, and does not start their resources again.
+func Reuse[T any](prev []Entry[T]) BuildOption {
+	return func(o *buildOptions) {
+		o.reuse = prev
+	}
+}
+
+// Entry is a configured middleware.
+type Entry[T any] struct {
+	// Name is the configured name, e.g. logging.
+	Name string
+	// Selectors are the operations the middleware applies to, empty applies to all.
+	Selectors []string
+	// Priority the lower runs first.
+	Priority   int32
+	Middleware T
+	// Close releases the resources of the middleware, nil if it holds none.
+	Close CloseFunc
+
+	// instance identifies the middleware instance shared by the builds reusing it.
+	instance *instance
+}
+
+type instance struct {
+	name    string
+	options *anypb.Any
+}
+
+// Match reports whether the middleware applies to the operation.
+func (e Entry[T]) Match(operation string) bool {
+	return matchSelectors(e.Selectors, operation)
+}
+
+// String returns the name with the selectors, e.g. logging[/helloworld.v1.Greeter/*].
+func (e Entry[T]) String() string {
+	if len(e.Selectors) == 0 {
+		return e.Name
+	}
+	return e.Name + "[" + strings.Join(e.Selectors, ",") + "]"
+}
+
+// Build builds the middlewares configured in `_ms`, sorted by priority in the configured order,
+// the first runs outermost, it was the last before the selectors and priority were added.
+// kind is the transport and the side, e.g. grpc.server or http.client.
+func Build(kind string, _ms []*configv1.Middleware, opts ...BuildOption) ([]Entry[middleware.Middleware], error) {
+	return build(kind, "middleware", _ms, opts, func(name string, m *configv1.Middleware) (middleware.Middleware, CloseFunc, error) {
+		mw, closeFn, err := createCloser(&configv1.Middleware{Name: name, Options: m.Options})
+		if errors.Is(err, ErrNotFound) && HasFilter(name) {
+			// registered as HTTP filter, see BuildFilter
+			return nil, nil, errFilter
+		}
+		return mw, closeFn, err
+	})
+}
+
+// BuildStream builds the stream middlewares configured in `_ms`, in the same order as Build.
+func BuildStream(kind string, _ms []*configv1.Middleware, opts ...BuildOption) ([]Entry[StreamMiddleware], error) {
+	return build(kind, "stream middleware", _ms, opts, func(name string, m *configv1.Middleware) (StreamMiddleware, CloseFunc, error) {
+		mw, closeFn, err := createStream(&configv1.Middleware{Name: name, Options: m.Options})
+		if errors.Is(err, ErrNotFound) && HasFilter(name) {
+			return nil, nil, errFilter
+		}
+		return mw, closeFn, err
+	})
+}
+
+// Validate checks the configured middleware: the name, the selectors and the options.
+// The middleware not registered is only reported in strict mode, it is skipped by Build otherwise.
+// The options are checked by creating the middleware with its registered factory, the created middleware is closed.
+func Validate(kind string, m *configv1.Middleware, opts ...BuildOption) error {
+	o := &buildOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	name, err := registeredName(kind, m.GetName())
+	if err != nil {
+		return err
+	}
+	if o.strict && !Has(name) && !HasStream(name) && !HasFilter(name) {
+		return fmt.Errorf("%q: %w", m.GetName(), ErrNotFound)
+	}
+	if err = validateSelectors(m.GetSelectors()); err != nil {
+		return err
+	}
+	if m.GetOptions() != nil {
+		if _, err = m.GetOptions().UnmarshalNew(); err != nil {
+			return fmt.Errorf("invalid options: %w", err)
+		}
+	}
+
+	cfg := &configv1.Middleware{Name: name, Options: m.GetOptions()}
+	var closeFn CloseFunc
+	switch {
+	case Has(name):
+		_, closeFn, err = createCloser(cfg)
+	case HasStream(name):
+		_, closeFn, err = createStream(cfg)
+	case HasFilter(name):
+		_, err = CreateFilter(cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+	if closeFn != nil {
+		if err = closeFn(); err != nil {
+			log.Errorf("close validated middleware %s error: %v", m.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// errFilter is the configured middleware registered as HTTP filter.
+var errFilter = errors.New("registered as HTTP filter")
+
+func build[T any](kind, typ string, _ms []*configv1.Middleware, opts []BuildOption,
+	create func(name string, m *configv1.Middleware) (T, CloseFunc, error)) ([]Entry[T], error) {
+	o := &buildOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	prev, _ := o.reuse.([]Entry[T])
+	reused := make([]bool, len(prev))
+
+	var (
+		errs    []error
+		created []Entry[T]
+	)
+	entries := make([]Entry[T], 0, len(_ms))
+	for i, m := range _ms {
+		name, err := registeredName(kind, m.GetName())
+		if err == nil {
+			err = validateSelectors(m.GetSelectors())
+		}
+		var (
+			mw      T
+			closeFn CloseFunc
+			inst    *instance
+		)
+		if err == nil {
+			if j := reusable(prev, reused, m); j >= 0 {
+				reused[j] = true
+				mw, closeFn, inst = prev[j].Middleware, prev[j].Close, prev[j].instance
+			} else {
+				mw, closeFn, err = create(name, m)
+				inst = &instance{name: m.GetName(), options: m.GetOptions()}
+				if err == nil && closeFn != nil {
+					created = append(created, Entry[T]{Name: m.GetName(), Close: closeFn, instance: inst})
+				}
+			}
+		}
+		if errors.Is(err, errFilter) {
+			continue
+		}
+		if errors.Is(err, ErrStreamUnsupported) {
+			// only runs for the unary RPCs, not an error of the config
+			log.Infof("Skip %s: [%s.%s] for streams: %v", typ, kind, m.GetName(), err)
+			continue
+		}
+		if err != nil {
+			err = fmt.Errorf("%s middlewares[%d] %s: %w", kind, i, m.GetName(), err)
+			if o.strict {
+				errs = append(errs, err)
+				continue
+			}
+			if errors.Is(err, ErrNotFound) {
+				log.Warnf("Skip does not exist %s: %v", typ, err)
+			} else {
+				log.Errorf("register %s error: %v", typ, err)
+			}
+			continue
+		}
+
+		entries = append(entries, Entry[T]{
+			Name:       m.GetName(),
+			Selectors:  m.GetSelectors(),
+			Priority:   m.GetPriority(),
+			Middleware: mw,
+			Close:      closeFn,
+			instance:   inst,
+		})
+	}
+	if len(errs) > 0 {
+		if err := CloseReplaced(created, nil); err != nil {
+			log.Errorf("close %s error: %v", typ, err)
+		}
+		return nil, errors.Join(errs...)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Priority < entries[j].Priority
+	})
+	for _, e := range entries {
+		log.Infof("register %s: [%s.%s] priority: %d success", typ, kind, e, e.Priority)
+	}
+	return entries, nil
+}
+
+// reusable returns the index of the previous entry not reused yet with the same name and options, -1 if none.
+func reusable[T any](prev []Entry[T], reused []bool, m *configv1.Middleware) int {
+	for i, e := range prev {
+		if reused[i] || e.instance == nil || e.instance.name != m.GetName() {
+			continue
+		}
+		if proto.Equal(e.instance.options, m.GetOptions()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CloseReplaced closes the middlewares of prev not reused by next, see Reuse.
+// A nil next closes all the middlewares of prev.
+func CloseReplaced[T any](prev, next []Entry[T]) error {
+	kept := make(map[*instance]bool, len(next))
+	for _, e := range next {
+		kept[e.instance] = true
+	}
+	var errs []error
+	for _, e := range prev {
+		if e.Close == nil || (e.instance != nil && kept[e.instance]) {
+			continue
+		}
+		if err := e.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("close %s: %w", e.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Middlewares returns the middlewares of the entries, the middlewares with selectors
+// only run for the selected operations.
+func Middlewares(entries []Entry[middleware.Middleware]) []middleware.Middleware {
+	ms := make([]middleware.Middleware, 0, len(entries))
+	for _, e := range entries {
+		if len(e.Selectors) == 0 {
+			ms = append(ms, e.Middleware)
+			continue
+		}
+		ms = append(ms, selectMiddleware(e))
+	}
+	return ms
+}
+
+// StreamMiddlewares returns the stream middlewares of the entries, see Middlewares.
+func StreamMiddlewares(entries []Entry[StreamMiddleware]) []StreamMiddleware {
+	ms := make([]StreamMiddleware, 0, len(entries))
+	for _, e := range entries {
+		if len(e.Selectors) == 0 {
+			ms = append(ms, e.Middleware)
+			continue
+		}
+		ms = append(ms, selectStreamMiddleware(e))
+	}
+	return ms
+}
+
+// Describe returns the names of the entries applying to the operation, in the running order.
+func Describe[T any](entries []Entry[T], operation string) []string {
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.Match(operation) {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+func selectMiddleware(e Entry[middleware.Middleware]) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		next := e.Middleware(handler)
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if e.Match(operation(ctx)) {
+				return next(ctx, req)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func selectStreamMiddleware(e Entry[StreamMiddleware]) StreamMiddleware {
+	return func(handler StreamHandler) StreamHandler {
+		next := e.Middleware(handler)
+		return func(ctx context.Context, stream Stream) error {
+			if e.Match(operation(ctx)) {
+				return next(ctx, stream)
+			}
+			return handler(ctx, stream)
+		}
+	}
+}
+
+// operation returns the operation of the server or client request.
+func operation(ctx context.Context) string {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		return tr.Operation()
+	}
+	if tr, ok := transport.FromClientContext(ctx); ok {
+		return tr.Operation()
+	}
+	return ""
+}
+
+// matchSelectors reports whether the operation is selected, the same as Server.Use:
+// the exact operation, or the prefix of the selector ending with '*'.
+func matchSelectors(selectors []string, operation string) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+	for _, s := range selectors {
+		if prefix, ok := strings.CutSuffix(s, "*"); ok {
+			if strings.HasPrefix(operation, prefix) {
+				return true
+			}
+		} else if s == operation {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSelectors(selectors []string) error {
+	for _, s := range selectors {
+		if !strings.HasPrefix(s, "/") {
+			return fmt.Errorf("invalid selector %q: must start with /", s)
+		}
+		if i := strings.Index(s, "*"); i >= 0 && i != len(s)-1 {
+			return fmt.Errorf("invalid selector %q: * is only allowed at the end", s)
+		}
+	}
+	return nil
+}
+
+// registeredName returns the registered name of a configured middleware,
+// e.g. kind `http.server` and name `cors` is registered as `server.cors`.
+func registeredName(kind, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("name is required")
+	}
+	side := kind
+	if i := strings.LastIndexByte(kind, '.'); i >= 0 {
+		side = kind[i+1:]
+	}
+	switch side {
+	case "server", "client":
+		return side + "." + name, nil
+	}
+	return "", fmt.Errorf("invalid kind %q", kind)
+}
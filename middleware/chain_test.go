@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	configv1 "github.com/nextmicro/next/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type Transport struct {
+	transport.Transporter
+	operation string
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+type tracesKey struct{}
+
+// tracer appends name to the traces of the context.
+func tracer(name string) Factory {
+	return func(c *configv1.Middleware) (middleware.Middleware, error) {
+		if c.Options != nil {
+			return nil, errors.New("invalid options")
+		}
+		return func(handler middleware.Handler) middleware.Handler {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				*ctx.Value(tracesKey{}).(*[]string) = append(*ctx.Value(tracesKey{}).(*[]string), name)
+				return handler(ctx, req)
+			}
+		}, nil
+	}
+}
+
+func init() {
+	Register("server.test.a", tracer("a"))
+	Register("server.test.b", tracer("b"))
+	Register("server.test.c", tracer("c"))
+}
+
+func run(ms []middleware.Middleware, operation string) []string {
+	var traces []string
+	ctx := context.WithValue(context.Background(), tracesKey{}, &traces)
+	ctx = transport.NewServerContext(ctx, &Transport{operation: operation})
+	h := middleware.Chain(ms...)(func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	_, _ = h(ctx, nil)
+	return traces
+}
+
+func TestBuild(t *testing.T) {
+	entries, err := Build("grpc.server", []*configv1.Middleware{
+		{Name: "test.a"},
+		{Name: "test.b", Selectors: []string{"/helloworld.v1.Greeter/*"}},
+		{Name: "test.c", Priority: -1, Selectors: []string{"/helloworld.v1.Greeter/SayHello", "/admin.v1.Admin/*"}},
+	})
+	assert.NoError(t, err)
+	ms := Middlewares(entries)
+
+	assert.Equal(t, []string{"c", "a", "b"}, run(ms, "/helloworld.v1.Greeter/SayHello"))
+	assert.Equal(t, []string{"a", "b"}, run(ms, "/helloworld.v1.Greeter/SayHi"))
+	assert.Equal(t, []string{"c", "a"}, run(ms, "/admin.v1.Admin/List"))
+	assert.Equal(t, []string{"a"}, run(ms, "/other.v1.Other/Get"))
+
+	assert.Equal(t, []string{"test.c", "test.a", "test.b"}, Describe(entries, "/helloworld.v1.Greeter/SayHello"))
+	assert.Equal(t, "test.b[/helloworld.v1.Greeter/*]", entries[2].String())
+}
+
+func TestBuildStrict(t *testing.T) {
+	invalid, _ := anypb.New(wrapperspb.String("invalid"))
+	ms := []*configv1.Middleware{
+		{Name: "test.a"},
+		{Name: "test.missing"},
+		{Name: "test.b", Options: invalid},
+		{Name: "test.c", Selectors: []string{"helloworld.v1.Greeter/*"}},
+		{Name: "test.c", Selectors: []string{"/helloworld.*.Greeter"}},
+	}
+
+	entries, err := Build("grpc.server", ms)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, err = Build("grpc.server", ms, Strict(true))
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorContains(t, err, "grpc.server middlewares[1] test.missing")
+	assert.ErrorContains(t, err, "grpc.server middlewares[2] test.b: invalid options")
+	assert.ErrorContains(t, err, "middlewares[3] test.c: invalid selector")
+	assert.ErrorContains(t, err, "middlewares[4] test.c: invalid selector")
+
+	_, err = Build("grpc.unknown", ms[:1], Strict(true))
+	assert.ErrorContains(t, err, `invalid kind "grpc.unknown"`)
+}
+
+// twice calls the handler twice, e.g. retrying.
+func twice(*configv1.Middleware) (middleware.Middleware, error) {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			_, _ = handler(ctx, req)
+			return handler(ctx, req)
+		}
+	}, nil
+}
+
+func TestBuildStream(t *testing.T) {
+	Register("client.test.twice", twice)
+	Register("client.test.safe", twice)
+	RegisterStreamSafe("client.test.safe")
+
+	entries, err := BuildStream("grpc.client", []*configv1.Middleware{{Name: "test.twice"}, {Name: "test.safe"}}, Strict(true))
+	assert.NoError(t, err)
+	// test.twice only runs for the unary RPCs
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "test.safe", entries[0].Name)
+
+	var calls int
+	h := StreamChain(StreamMiddlewares(entries)...)(func(context.Context, Stream) error {
+		calls++
+		return nil
+	})
+	assert.ErrorIs(t, h(context.Background(), nil), ErrStreamHandlerCalled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRegisteredName(t *testing.T) {
+	for kind, expected := range map[string]string{
+		"grpc.server": "server.cors",
+		"http.client": "client.cors",
+		"server":      "server.cors",
+	} {
+		name, err := registeredName(kind, "cors")
+		assert.NoError(t, err)
+		assert.Equal(t, expected, name)
+	}
+}
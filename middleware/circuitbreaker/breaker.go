@@ -0,0 +1,130 @@
+package circuitbreaker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	log "github.com/nextmicro/logger"
+)
+
+// State is the state of the circuit breaker.
+type State int32
+
+const (
+	// StateClosed the requests are allowed.
+	StateClosed State = iota
+	// StateOpen the requests are rejected.
+	StateOpen
+	// StateHalfOpen a request is allowed after open, closed if it succeeds.
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+const (
+	// logInterval limits the state change logs of a breaker, the SRE breaker drops a part of
+	// the requests, so the state may change on almost every request while it is dropping.
+	logInterval = 10 * time.Second
+	// skipInterval is how long a node is skipped after its breaker rejected a request,
+	// then a request is allowed to probe it.
+	skipInterval = time.Second
+)
+
+// breaker tracks the state of the circuit breaker,
+// it is open when a request is rejected, half-open when a request is allowed after open
+// and closed when the allowed request succeeds.
+type breaker struct {
+	circuitbreaker.CircuitBreaker
+
+	mu       sync.Mutex
+	state    State
+	rejected time.Time
+	logged   time.Time
+}
+
+func newBreaker(c interface{}) interface{} {
+	return &breaker{CircuitBreaker: c.(circuitbreaker.CircuitBreaker)}
+}
+
+func (b *breaker) allow(o *Options, operation, node string) error {
+	if err := b.Allow(); err != nil {
+		// NOTE: when client reject requests locally,
+		// continue to add counter let the drop ratio higher.
+		b.MarkFailed()
+		b.mu.Lock()
+		b.rejected = time.Now()
+		b.mu.Unlock()
+		b.transit(o, operation, node, StateOpen)
+		return err
+	}
+	b.transit(o, operation, node, StateHalfOpen, StateOpen)
+	return nil
+}
+
+func (b *breaker) mark(o *Options, operation, node string, err error) {
+	if isFailure(err) {
+		b.MarkFailed()
+		b.transit(o, operation, node, StateOpen, StateHalfOpen)
+		return
+	}
+	b.MarkSuccess()
+	b.transit(o, operation, node, StateClosed)
+}
+
+// skipped reports whether the node is skipped, its breaker rejected a request recently.
+func (b *breaker) skipped() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == StateOpen && time.Since(b.rejected) < skipInterval
+}
+
+// transit sets the state to `to` if the current state is one of `from`, any state if `from` is empty.
+func (b *breaker) transit(o *Options, operation, node string, to State, from ...State) {
+	b.mu.Lock()
+	prev := b.state
+	if prev == to || (len(from) > 0 && !contains(from, prev)) {
+		b.mu.Unlock()
+		return
+	}
+	b.state = to
+	now := time.Now()
+	logged := now.Sub(b.logged) >= logInterval
+	if logged {
+		b.logged = now
+	}
+	b.mu.Unlock()
+
+	if logged {
+		if node != "" {
+			log.Debugf("circuit breaker [%s] node [%s] state changed: %s -> %s", operation, node, prev, to)
+		} else {
+			log.Debugf("circuit breaker [%s] state changed: %s -> %s", operation, prev, to)
+		}
+	}
+	if o.state != nil {
+		o.state.With(operation, node).Set(float64(to))
+	}
+	if o.transitions != nil {
+		o.transitions.With(operation, node, prev.String(), to.String()).Inc()
+	}
+}
+
+func contains(states []State, s State) bool {
+	for _, state := range states {
+		if state == s {
+			return true
+		}
+	}
+	return false
+}
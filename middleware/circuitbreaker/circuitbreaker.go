@@ -2,20 +2,31 @@ package circuitbreaker
 
 import (
 	"context"
+	"fmt"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-kratos/aegis/circuitbreaker"
 	"github.com/go-kratos/aegis/circuitbreaker/sre"
+	prom "github.com/go-kratos/kratos/contrib/metrics/prometheus/v2"
+	lru "github.com/hashicorp/golang-lru"
 	log "github.com/nextmicro/logger"
 	config "github.com/nextmicro/next/api/config/v1"
 	v1 "github.com/nextmicro/next/api/middleware/circuitbreaker/v1"
+	"github.com/nextmicro/next/internal/group"
 	chain "github.com/nextmicro/next/middleware"
+	metric "github.com/nextmicro/next/pkg/metrics"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
+	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
 	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metrics"
 	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
 )
 
 // ErrNotAllowed is request failed due to circuit breaker triggered.
@@ -23,6 +34,7 @@ var ErrNotAllowed = errors.New(503, "CIRCUITBREAKER", "request failed due to cir
 
 func init() {
 	chain.Register("client.circuitbreaker", injection)
+	chain.RegisterStreamSafe("client.circuitbreaker")
 }
 
 type ratioTrigger struct {
@@ -74,6 +86,8 @@ func makeBreakerTrigger(in *v1.CircuitBreaker) circuitbreaker.CircuitBreaker {
 		return sre.NewBreaker(opts...)
 	case *v1.CircuitBreaker_Ratio:
 		return newRatioTrigger(trigger)
+	case nil:
+		return nopTrigger{}
 	default:
 		log.Warnf("Unrecoginzed circuit breaker trigger: %+v", trigger)
 		return nopTrigger{}
@@ -87,57 +101,234 @@ func injection(c *config.Middleware) (middleware.Middleware, error) {
 			return nil, err
 		}
 	}
+	if options.Trigger == nil {
+		log.Warnf("Unrecoginzed circuit breaker trigger: %+v", options.Trigger)
+	}
 
-	opts := make([]Option, 0, 1)
-	breaker := makeBreakerTrigger(options)
-	if breaker != nil {
-		opts = append(opts, WithCircuitBreaker(breaker))
+	opts := make([]Option, 0, 3)
+	opts = append(opts,
+		WithGroup(group.NewGroup(func() interface{} {
+			return makeBreakerTrigger(options)
+		})),
+		WithPerNode(options.GetPerNode()),
+	)
+	if name := options.GetFallback(); name != "" {
+		fallback, ok := getFallback(name)
+		if !ok {
+			return nil, fmt.Errorf("circuitbreaker: fallback %q has not been registered", name)
+		}
+		opts = append(opts, WithFallback(fallback))
 	}
 
 	return Client(opts...), nil
 }
 
+// Fallback runs when the request is rejected by the circuit breaker, `err` is ErrNotAllowed.
+type Fallback func(ctx context.Context, req interface{}, err error) (interface{}, error)
+
+var (
+	fallbackMu sync.RWMutex
+	fallbacks  = map[string]Fallback{}
+)
+
+// RegisterFallback registers the fallback referenced by name in the middleware config.
+func RegisterFallback(name string, fallback Fallback) {
+	fallbackMu.Lock()
+	defer fallbackMu.Unlock()
+	fallbacks[name] = fallback
+}
+
+func getFallback(name string) (Fallback, bool) {
+	fallbackMu.RLock()
+	defer fallbackMu.RUnlock()
+	fallback, ok := fallbacks[name]
+	return fallback, ok
+}
+
 // Option represents options update func
 type Option func(*Options)
 
 // Options represents hystrix client wrapper options
 type Options struct {
-	breaker circuitbreaker.CircuitBreaker
+	group    *group.Group
+	perNode  bool
+	maxNodes int
+	// nodes are the breakers of the operations and nodes, the least recently used are evicted
+	nodes    *lru.Cache
+	fallback Fallback
+
+	// gauge: client_circuitbreaker_state{operation, node}
+	state metrics.Gauge
+	// counter: client_circuitbreaker_transitions_total{operation, node, from, to}
+	transitions metrics.Counter
 }
 
+// WithCircuitBreaker with the circuit breaker shared by all the operations.
 func WithCircuitBreaker(c circuitbreaker.CircuitBreaker) Option {
 	return func(o *Options) {
-		o.breaker = c
+		o.group = group.NewGroup(func() interface{} {
+			return c
+		})
+	}
+}
+
+// WithGroup with the group creating a circuit breaker for each operation or node.
+func WithGroup(g *group.Group) Option {
+	return func(o *Options) {
+		o.group = g
+	}
+}
+
+// WithPerNode with the circuit breakers keyed by the operation and the selected node.
+func WithPerNode(perNode bool) Option {
+	return func(o *Options) {
+		o.perNode = perNode
+	}
+}
+
+// WithMaxNodes with the breakers kept for the operations and nodes, the least recently used are evicted
+// with their metrics, e.g. the nodes gone by scaling in, defaults to 10000.
+func WithMaxNodes(n int) Option {
+	return func(o *Options) {
+		o.maxNodes = n
+	}
+}
+
+// WithFallback with the fallback which runs when the request is rejected.
+func WithFallback(fallback Fallback) Option {
+	return func(o *Options) {
+		o.fallback = fallback
+	}
+}
+
+// WithState with the state gauge.
+func WithState(g metrics.Gauge) Option {
+	return func(o *Options) {
+		o.state = g
+	}
+}
+
+// WithTransitions with the state transitions counter.
+func WithTransitions(c metrics.Counter) Option {
+	return func(o *Options) {
+		o.transitions = c
 	}
 }
 
 // Client circuitbreaker middleware will return errBreakerTriggered when the circuit
 // breaker is triggered and the request is rejected directly.
+// The breakers are created lazily for each operation, or each operation and node with WithPerNode.
 func Client(opts ...Option) middleware.Middleware {
 	options := Options{
-		breaker: sre.NewBreaker(),
+		group: group.NewGroup(func() interface{} {
+			return sre.NewBreaker()
+		}),
+		fallback: func(_ context.Context, _ interface{}, err error) (interface{}, error) {
+			return nil, err
+		},
+		maxNodes:    defaultMaxNodes,
+		state:       prom.NewGauge(metric.ClientMetricCircuitBreakerState),
+		transitions: prom.NewCounter(metric.ClientMetricCircuitBreakerTransitions),
 	}
 	for _, o := range opts {
 		o(&options)
 	}
+	// the breakers track the state of the circuit breakers created by the group
+	options.group = options.group.Wrap(newBreaker)
+	if options.maxNodes <= 0 {
+		options.maxNodes = defaultMaxNodes
+	}
+	if options.perNode {
+		options.nodes, _ = lru.NewWithEvict(options.maxNodes, func(key, _ interface{}) {
+			k := key.(nodeKey)
+			metric.ClientMetricCircuitBreakerState.DeleteLabelValues(k.operation, k.node)
+			metric.ClientMetricCircuitBreakerTransitions.DeletePartialMatch(prometheus.Labels{"operation": k.operation, "node": k.node})
+		})
+	}
 
 	return func(handler middleware.Handler) middleware.Handler {
 		return func(ctx context.Context, req interface{}) (interface{}, error) {
-			if err := options.breaker.Allow(); err != nil {
-				// rejected
-				// NOTE: when client reject requests locally,
-				// continue to add counter let the drop ratio higher.
-				options.breaker.MarkFailed()
-				return nil, ErrNotAllowed
+			var operation string
+			if tr, ok := transport.FromClientContext(ctx); ok {
+				operation = tr.Operation()
+			}
+			if !options.perNode {
+				return options.call(ctx, req, handler, operation)
 			}
-			// allowed
-			reply, err := handler(ctx, req)
-			if err != nil && (errors.IsInternalServer(err) || errors.IsServiceUnavailable(err) || errors.IsGatewayTimeout(err)) {
-				options.breaker.MarkFailed()
-			} else {
-				options.breaker.MarkSuccess()
+			return options.callNode(ctx, req, handler, operation)
+		}
+	}
+}
+
+const defaultMaxNodes = 10000
+
+type nodeKey struct {
+	operation string
+	node      string
+}
+
+// breaker returns the breaker of the operation, or of the operation and node.
+func (o *Options) breaker(operation, node string) *breaker {
+	if node == "" {
+		return o.group.Get(operation).(*breaker)
+	}
+	key := nodeKey{operation: operation, node: node}
+	if b, ok := o.nodes.Get(key); ok {
+		return b.(*breaker)
+	}
+	b := o.group.New()
+	if prev, ok, _ := o.nodes.PeekOrAdd(key, b); ok {
+		b = prev
+	}
+	return b.(*breaker)
+}
+
+func (o *Options) call(ctx context.Context, req interface{}, handler middleware.Handler, operation string) (interface{}, error) {
+	b := o.breaker(operation, "")
+	if err := b.allow(o, operation, ""); err != nil {
+		return o.fallback(ctx, req, ErrNotAllowed)
+	}
+	reply, err := handler(ctx, req)
+	b.mark(o, operation, "", err)
+	return reply, err
+}
+
+// callNode skips the nodes whose breakers rejected a request recently, unless all of them did,
+// and the request is rejected by the breaker of the selected node.
+func (o *Options) callNode(ctx context.Context, req interface{}, handler middleware.Handler, operation string) (interface{}, error) {
+	var rejected atomic.Bool
+	ctx = nodeselector.NewFilterContext(ctx, func(_ context.Context, nodes []selector.Node) []selector.Node {
+		allowed := make([]selector.Node, 0, len(nodes))
+		for _, node := range nodes {
+			if !o.breaker(operation, node.Address()).skipped() {
+				allowed = append(allowed, node)
 			}
-			return reply, err
 		}
+		if len(allowed) == 0 {
+			return nodes
+		}
+		return allowed
+	})
+	ctx = nodeselector.NewSelectedContext(ctx, func(node selector.Node) error {
+		err := o.breaker(operation, node.Address()).allow(o, operation, node.Address())
+		// the last selected node decides, e.g. the retries of the call
+		rejected.Store(err != nil)
+		if err != nil {
+			return ErrNotAllowed
+		}
+		return nil
+	})
+
+	reply, err := handler(ctx, req)
+	if rejected.Load() {
+		return o.fallback(ctx, req, ErrNotAllowed)
 	}
+	if p, ok := selector.FromPeerContext(ctx); ok && p.Node != nil {
+		o.breaker(operation, p.Node.Address()).mark(o, operation, p.Node.Address(), err)
+	}
+	return reply, err
+}
+
+func isFailure(err error) bool {
+	return err != nil && (errors.IsInternalServer(err) || errors.IsServiceUnavailable(err) || errors.IsGatewayTimeout(err))
 }
@@ -0,0 +1,185 @@
+package circuitbreaker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/nextmicro/next/internal/group"
+	metric "github.com/nextmicro/next/pkg/metrics"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
+	"github.com/stretchr/testify/assert"
+)
+
+type Transport struct {
+	transport.Transporter
+	operation string
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+// fakeBreaker rejects the requests after a failure.
+type fakeBreaker struct {
+	failed bool
+	allows int
+}
+
+func (b *fakeBreaker) Allow() error {
+	b.allows++
+	if b.failed {
+		return circuitbreaker.ErrNotAllowed
+	}
+	return nil
+}
+func (b *fakeBreaker) MarkSuccess() { b.failed = false }
+func (b *fakeBreaker) MarkFailed()  { b.failed = true }
+
+type transitions struct {
+	metrics.Counter
+	labels [][]string
+}
+
+func (c *transitions) With(lvs ...string) metrics.Counter {
+	c.labels = append(c.labels, lvs)
+	return c
+}
+
+func (c *transitions) Inc() {}
+
+func newGroup() *group.Group {
+	return group.NewGroup(func() interface{} {
+		return &fakeBreaker{}
+	})
+}
+
+func TestClient(t *testing.T) {
+	counter := &transitions{}
+	m := Client(
+		WithGroup(newGroup()),
+		WithTransitions(counter),
+		WithFallback(func(ctx context.Context, req interface{}, err error) (interface{}, error) {
+			return "fallback", nil
+		}),
+	)
+	failed := m(func(context.Context, interface{}) (interface{}, error) {
+		return nil, errors.ServiceUnavailable("UNAVAILABLE", "")
+	})
+	ok := m(func(context.Context, interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+
+	a := transport.NewClientContext(context.Background(), &Transport{operation: "/a"})
+	b := transport.NewClientContext(context.Background(), &Transport{operation: "/b"})
+
+	_, err := failed(a, nil)
+	assert.True(t, errors.IsServiceUnavailable(err))
+
+	// the breaker of /a is open, /b is not affected
+	reply, err := ok(a, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", reply)
+	reply, err = ok(b, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+
+	assert.Equal(t, [][]string{{"/a", "", "closed", "open"}}, counter.labels)
+}
+
+func TestClientPerNode(t *testing.T) {
+	var breakers []*fakeBreaker
+	m := Client(WithGroup(group.NewGroup(func() interface{} {
+		b := &fakeBreaker{}
+		breakers = append(breakers, b)
+		return b
+	})), WithPerNode(true))
+
+	nodes := []selector.Node{
+		selector.NewNode("grpc", "127.0.0.1:9000", &registry.ServiceInstance{}),
+		selector.NewNode("grpc", "127.0.0.1:9001", &registry.ServiceInstance{}),
+	}
+	var selected []string
+	// selects the first node left by the filters, the first node fails
+	handler := m(func(ctx context.Context, req interface{}) (interface{}, error) {
+		candidates := nodes
+		for _, filter := range nodeselector.FiltersFromContext(ctx) {
+			candidates = filter(ctx, candidates)
+		}
+		if len(candidates) == 0 {
+			return nil, selector.ErrNoAvailable
+		}
+		p, _ := selector.FromPeerContext(ctx)
+		p.Node = candidates[0]
+		selected = append(selected, p.Node.Address())
+		if err := nodeselector.Selected(ctx, p.Node); err != nil {
+			return nil, err
+		}
+		if p.Node.Address() == "127.0.0.1:9000" {
+			return nil, errors.InternalServer("INTERNAL", "")
+		}
+		return "ok", nil
+	})
+
+	call := func() (interface{}, error) {
+		ctx := transport.NewClientContext(context.Background(), &Transport{operation: "/a"})
+		return handler(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+	}
+	_, err := call()
+	assert.True(t, errors.IsInternalServer(err))
+	// only the breaker of the selected node is checked
+	assert.Len(t, breakers, 2)
+	assert.Equal(t, 1, breakers[0].allows)
+	assert.Equal(t, 0, breakers[1].allows)
+
+	// the selected node is rejected, then it is skipped
+	_, err = call()
+	assert.Equal(t, ErrNotAllowed, err)
+	reply, err := call()
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+	assert.Equal(t, []string{"127.0.0.1:9000", "127.0.0.1:9000", "127.0.0.1:9001"}, selected)
+
+	// the skipped node is selected if no node is left
+	nodes = nodes[:1]
+	_, err = call()
+	assert.Equal(t, ErrNotAllowed, err)
+	assert.Equal(t, "127.0.0.1:9000", selected[len(selected)-1])
+}
+
+func TestClientMaxNodes(t *testing.T) {
+	var breakers int
+	m := Client(WithGroup(group.NewGroup(func() interface{} {
+		breakers++
+		return &fakeBreaker{}
+	})), WithPerNode(true), WithMaxNodes(1))
+
+	call := func(addr string) {
+		handler := m(func(ctx context.Context, req interface{}) (interface{}, error) {
+			p, _ := selector.FromPeerContext(ctx)
+			p.Node = selector.NewNode("grpc", addr, &registry.ServiceInstance{})
+			if err := nodeselector.Selected(ctx, p.Node); err != nil {
+				return nil, err
+			}
+			return nil, errors.InternalServer("INTERNAL", "")
+		})
+		ctx := transport.NewClientContext(context.Background(), &Transport{operation: "/maxnodes"})
+		_, _ = handler(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+	}
+
+	// the breaker is open after a failure
+	call("127.0.0.1:9000")
+	call("127.0.0.1:9000")
+	call("127.0.0.1:9001")
+	call("127.0.0.1:9001")
+	// the breaker of the first node is evicted with its metrics
+	assert.False(t, metric.ClientMetricCircuitBreakerState.DeleteLabelValues("/maxnodes", "127.0.0.1:9000"))
+	assert.True(t, metric.ClientMetricCircuitBreakerState.DeleteLabelValues("/maxnodes", "127.0.0.1:9001"))
+	call("127.0.0.1:9000")
+	assert.Equal(t, 3, breakers)
+}
@@ -0,0 +1,297 @@
+package cors
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/cors/v1"
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const namespace = "cors"
+
+const (
+	headerOrigin                        = "Origin"
+	headerVary                          = "Vary"
+	headerRequestMethod                 = "Access-Control-Request-Method"
+	headerRequestHeaders                = "Access-Control-Request-Headers"
+	headerRequestPrivateNetwork         = "Access-Control-Request-Private-Network"
+	headerAllowOrigin                   = "Access-Control-Allow-Origin"
+	headerAllowMethods                  = "Access-Control-Allow-Methods"
+	headerAllowHeaders                  = "Access-Control-Allow-Headers"
+	headerAllowCredentials              = "Access-Control-Allow-Credentials"
+	headerAllowPrivateNetwork           = "Access-Control-Allow-Private-Network"
+	headerExposeHeaders                 = "Access-Control-Expose-Headers"
+	headerMaxAge                        = "Access-Control-Max-Age"
+	defaultPreflightStatus              = http.StatusNoContent
+	defaultPreflightVary                = "Origin, Access-Control-Request-Method, Access-Control-Request-Headers"
+	defaultPreflightPrivateNetworkValue = "true"
+)
+
+func init() {
+	chain.RegisterFilter("server."+namespace, injection)
+}
+
+func injection(c *config.Middleware) (func(http.Handler) http.Handler, error) {
+	cfg := &v1.Cors{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.AllowCredentials {
+		// the credentialed requests are only allowed from the listed origins
+		if len(cfg.AllowOrigins) == 0 {
+			return nil, errors.New("cors: allow_origins is required with allow_credentials")
+		}
+		for _, origin := range cfg.AllowOrigins {
+			if origin == "*" {
+				return nil, errors.New("cors: allow_origins * is not allowed with allow_credentials")
+			}
+		}
+	}
+
+	opts := make([]Option, 0)
+	if len(cfg.AllowOrigins) > 0 {
+		opts = append(opts, WithAllowOrigins(cfg.AllowOrigins...))
+	}
+	if len(cfg.AllowMethods) > 0 {
+		opts = append(opts, WithAllowMethods(cfg.AllowMethods...))
+	}
+	if len(cfg.AllowHeaders) > 0 {
+		opts = append(opts, WithAllowHeaders(cfg.AllowHeaders...))
+	}
+	if len(cfg.ExposeHeaders) > 0 {
+		opts = append(opts, WithExposeHeaders(cfg.ExposeHeaders...))
+	}
+	if cfg.MaxAge != nil && cfg.MaxAge.AsDuration() > 0 {
+		opts = append(opts, WithMaxAge(cfg.MaxAge.AsDuration()))
+	}
+	if cfg.AllowCredentials {
+		opts = append(opts, WithAllowCredentials(cfg.AllowCredentials))
+	}
+	if cfg.AllowPrivateNetwork {
+		opts = append(opts, WithAllowPrivateNetwork(cfg.AllowPrivateNetwork))
+	}
+
+	return Filter(opts...), nil
+}
+
+type cors struct {
+	allowAllOrigins bool
+	allowOrigins    []string
+	wildcards       []wildcard
+	allowOriginFunc func(origin string) bool
+	allowMethods    []string
+	allowHeaders    []string
+	allowAllHeaders bool
+	options         options
+}
+
+func newCors(opts ...Option) *cors {
+	o := options{
+		allowOrigins: []string{"*"},
+		allowMethods: defaultAllowMethods,
+		allowHeaders: defaultAllowHeaders,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &cors{
+		allowOriginFunc: o.allowOriginFunc,
+		allowMethods:    convert(o.allowMethods, strings.ToUpper),
+		allowHeaders:    convert(o.allowHeaders, http.CanonicalHeaderKey),
+		options:         o,
+	}
+	for _, origin := range o.allowOrigins {
+		origin = strings.ToLower(origin)
+		if origin == "*" {
+			c.allowAllOrigins = true
+			c.allowOrigins = nil
+			c.wildcards = nil
+			break
+		}
+		if i := strings.IndexByte(origin, '*'); i >= 0 {
+			c.wildcards = append(c.wildcards, wildcard{prefix: origin[:i], suffix: origin[i+1:]})
+			continue
+		}
+		c.allowOrigins = append(c.allowOrigins, origin)
+	}
+	for _, h := range o.allowHeaders {
+		if h == "*" {
+			c.allowAllHeaders = true
+			break
+		}
+	}
+	return c
+}
+
+// Filter is a HTTP filter that handles the Cross-Origin Resource Sharing.
+// The preflight requests are answered before routing, so the handlers
+// don't need to register OPTIONS routes.
+func Filter(opts ...Option) func(http.Handler) http.Handler {
+	c := newCors(opts...)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions && r.Header.Get(headerRequestMethod) != "" {
+				c.handlePreflight(w, r)
+				w.WriteHeader(defaultPreflightStatus)
+				return
+			}
+
+			c.handleActual(w, r)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (c *cors) handlePreflight(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Add(headerVary, defaultPreflightVary)
+	if c.options.allowPrivateNetwork {
+		header.Add(headerVary, headerRequestPrivateNetwork)
+	}
+
+	origin := r.Header.Get(headerOrigin)
+	if origin == "" || !c.isOriginAllowed(origin) {
+		return
+	}
+	method := strings.ToUpper(r.Header.Get(headerRequestMethod))
+	if !c.isMethodAllowed(method) {
+		return
+	}
+	reqHeaders := parseHeaderList(r.Header.Get(headerRequestHeaders))
+	if !c.areHeadersAllowed(reqHeaders) {
+		return
+	}
+
+	c.setAllowOrigin(header, origin)
+	header.Set(headerAllowMethods, method)
+	if len(reqHeaders) > 0 {
+		header.Set(headerAllowHeaders, strings.Join(reqHeaders, ", "))
+	}
+	if c.allowCredentials() {
+		header.Set(headerAllowCredentials, "true")
+	}
+	if c.options.allowPrivateNetwork && r.Header.Get(headerRequestPrivateNetwork) == defaultPreflightPrivateNetworkValue {
+		header.Set(headerAllowPrivateNetwork, defaultPreflightPrivateNetworkValue)
+	}
+	if c.options.maxAge > 0 {
+		header.Set(headerMaxAge, strconv.FormatInt(int64(c.options.maxAge.Seconds()), 10))
+	}
+}
+
+func (c *cors) handleActual(w http.ResponseWriter, r *http.Request) {
+	header := w.Header()
+	header.Add(headerVary, headerOrigin)
+
+	origin := r.Header.Get(headerOrigin)
+	if origin == "" || !c.isOriginAllowed(origin) {
+		return
+	}
+	if !c.isMethodAllowed(r.Method) {
+		return
+	}
+
+	c.setAllowOrigin(header, origin)
+	if len(c.options.exposeHeaders) > 0 {
+		header.Set(headerExposeHeaders, strings.Join(c.options.exposeHeaders, ", "))
+	}
+	if c.allowCredentials() {
+		header.Set(headerAllowCredentials, "true")
+	}
+}
+
+// setAllowOrigin writes the allowed origin, the wildcard if all the origins are allowed.
+func (c *cors) setAllowOrigin(header http.Header, origin string) {
+	if c.allowAnyOrigin() {
+		header.Set(headerAllowOrigin, "*")
+		return
+	}
+	header.Set(headerAllowOrigin, origin)
+}
+
+// allowAnyOrigin reports whether any origin is allowed without checking it.
+func (c *cors) allowAnyOrigin() bool {
+	return c.allowAllOrigins && c.allowOriginFunc == nil
+}
+
+// allowCredentials reports whether the credentials are allowed, they are never allowed for any origin,
+// reflecting the origin would let any site send the credentialed requests.
+func (c *cors) allowCredentials() bool {
+	return c.options.allowCredentials && !c.allowAnyOrigin()
+}
+
+func (c *cors) isOriginAllowed(origin string) bool {
+	if c.allowOriginFunc != nil {
+		return c.allowOriginFunc(origin)
+	}
+	if c.allowAllOrigins {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, o := range c.allowOrigins {
+		if o == origin {
+			return true
+		}
+	}
+	for _, w := range c.wildcards {
+		if w.match(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *cors) isMethodAllowed(method string) bool {
+	if method == http.MethodOptions {
+		// always allow preflight requests
+		return true
+	}
+	for _, m := range c.allowMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *cors) areHeadersAllowed(headers []string) bool {
+	if c.allowAllHeaders || len(headers) == 0 {
+		return true
+	}
+	for _, h := range headers {
+		found := false
+		for _, allowed := range c.allowHeaders {
+			if h == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// parseHeaderList parses the comma separated header list, e.g. `content-type, x-md-global-uid`.
+func parseHeaderList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	headers := make([]string, 0, strings.Count(list, ",")+1)
+	for _, h := range strings.Split(list, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			headers = append(headers, http.CanonicalHeaderKey(h))
+		}
+	}
+	return headers
+}
@@ -0,0 +1,128 @@
+package cors_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/cors/v1"
+	chain "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/middleware/cors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestPreflight(t *testing.T) {
+	h := cors.Filter(
+		cors.WithAllowOrigins("https://*.example.com", "http://localhost:8080"),
+		cors.WithAllowHeaders("Content-Type", "X-Md-Global-Uid"),
+		cors.WithMaxAge(time.Hour),
+		cors.WithAllowPrivateNetwork(true),
+	)(http.NotFoundHandler())
+
+	req := httptest.NewRequest(http.MethodOptions, "/users/me", nil)
+	req.Header.Set("Origin", "https://api.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type, x-md-global-uid")
+	req.Header.Set("Access-Control-Request-Private-Network", "true")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://api.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, http.MethodPost, rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Content-Type, X-Md-Global-Uid", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "3600", rec.Header().Get("Access-Control-Max-Age"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Private-Network"))
+}
+
+func TestPreflightDenied(t *testing.T) {
+	h := cors.Filter(cors.WithAllowOrigins("https://*.example.com"))(okHandler)
+
+	tests := []struct {
+		name    string
+		origin  string
+		method  string
+		headers string
+	}{
+		{name: "origin", origin: "https://example.org", method: http.MethodGet},
+		{name: "suffix", origin: "https://api.example.com.evil.org", method: http.MethodGet},
+		{name: "method", origin: "https://api.example.com", method: "PROPFIND"},
+		{name: "header", origin: "https://api.example.com", method: http.MethodGet, headers: "x-unknown"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/", nil)
+			req.Header.Set("Origin", test.origin)
+			req.Header.Set("Access-Control-Request-Method", test.method)
+			req.Header.Set("Access-Control-Request-Headers", test.headers)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestActual(t *testing.T) {
+	h := cors.Filter(
+		cors.WithAllowOrigins("http://localhost:3000"),
+		cors.WithAllowCredentials(true),
+		cors.WithExposeHeaders("X-Trace-Id"),
+	)(okHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "http://localhost:3000", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "X-Trace-Id", rec.Header().Get("Access-Control-Expose-Headers"))
+	assert.Equal(t, "Origin", rec.Header().Get("Vary"))
+}
+
+func TestActualAnyOriginWithCredentials(t *testing.T) {
+	h := cors.Filter(cors.WithAllowOrigins("*"), cors.WithAllowCredentials(true))(okHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "http://evil.example.com")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	// the origin is not reflected, the credentials are not allowed
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestInjectionCredentials(t *testing.T) {
+	for _, origins := range [][]string{nil, {"http://localhost:3000", "*"}} {
+		options, err := anypb.New(&v1.Cors{AllowCredentials: true, AllowOrigins: origins})
+		assert.NoError(t, err)
+		assert.Error(t, chain.Validate("http.server", &config.Middleware{Name: "cors", Options: options}), origins)
+	}
+
+	options, err := anypb.New(&v1.Cors{AllowCredentials: true, AllowOrigins: []string{"https://*.example.com"}})
+	assert.NoError(t, err)
+	assert.NoError(t, chain.Validate("http.server", &config.Middleware{Name: "cors", Options: options}))
+}
+
+func TestActualWithoutOrigin(t *testing.T) {
+	h := cors.Filter()(okHandler)
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	// not a preflight request, passed to the handler
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
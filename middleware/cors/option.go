@@ -0,0 +1,126 @@
+package cors
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	defaultAllowMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPut,
+		http.MethodPatch,
+		http.MethodDelete,
+		http.MethodHead,
+		http.MethodOptions,
+	}
+	defaultAllowHeaders = []string{
+		"Origin",
+		"Content-Type",
+		"Accept",
+		"Authorization",
+		"X-Requested-With",
+	}
+)
+
+// Option is cors option.
+type Option func(*options)
+
+type options struct {
+	// allowOrigins is a list of origins a cross-domain request can be executed from.
+	// "*" allows all origins, and an origin may contain one wildcard,
+	// e.g. "https://*.example.com" matches every subdomain of example.com.
+	allowOrigins []string
+	// allowOriginFunc is a custom function to validate the origin,
+	// it takes precedence over allowOrigins.
+	allowOriginFunc func(origin string) bool
+	// allowMethods is a list of methods the client is allowed to use.
+	allowMethods []string
+	// allowHeaders is a list of non simple headers the client is allowed to use.
+	allowHeaders []string
+	// exposeHeaders indicates which headers are safe to expose.
+	exposeHeaders []string
+	// maxAge indicates how long the results of a preflight request can be cached.
+	maxAge time.Duration
+	// allowCredentials indicates whether the request can include user credentials.
+	allowCredentials bool
+	// allowPrivateNetwork indicates whether to accept cross-origin requests over a private network.
+	allowPrivateNetwork bool
+}
+
+// WithAllowOrigins with allowed origins.
+func WithAllowOrigins(origins ...string) Option {
+	return func(o *options) {
+		o.allowOrigins = origins
+	}
+}
+
+// WithAllowOriginFunc with custom origin validate function.
+func WithAllowOriginFunc(fn func(origin string) bool) Option {
+	return func(o *options) {
+		o.allowOriginFunc = fn
+	}
+}
+
+// WithAllowMethods with allowed methods.
+func WithAllowMethods(methods ...string) Option {
+	return func(o *options) {
+		o.allowMethods = methods
+	}
+}
+
+// WithAllowHeaders with allowed headers.
+func WithAllowHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.allowHeaders = headers
+	}
+}
+
+// WithExposeHeaders with exposed headers.
+func WithExposeHeaders(headers ...string) Option {
+	return func(o *options) {
+		o.exposeHeaders = headers
+	}
+}
+
+// WithMaxAge with preflight cache max age.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(o *options) {
+		o.maxAge = maxAge
+	}
+}
+
+// WithAllowCredentials with allow credentials, they are not allowed if any origin is allowed.
+func WithAllowCredentials(allow bool) Option {
+	return func(o *options) {
+		o.allowCredentials = allow
+	}
+}
+
+// WithAllowPrivateNetwork with allow private network.
+func WithAllowPrivateNetwork(allow bool) Option {
+	return func(o *options) {
+		o.allowPrivateNetwork = allow
+	}
+}
+
+// wildcard is an origin pattern with one `*`.
+type wildcard struct {
+	prefix string
+	suffix string
+}
+
+func (w wildcard) match(s string) bool {
+	return len(s) >= len(w.prefix)+len(w.suffix) && strings.HasPrefix(s, w.prefix) && strings.HasSuffix(s, w.suffix)
+}
+
+// convert converts the values with fn.
+func convert(s []string, fn func(string) string) []string {
+	out := make([]string, 0, len(s))
+	for _, v := range s {
+		out = append(out, fn(v))
+	}
+	return out
+}
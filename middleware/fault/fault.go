@@ -0,0 +1,345 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"path"
+	"sync/atomic"
+	"time"
+
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	log "github.com/nextmicro/logger"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/fault/v1"
+	conf "github.com/nextmicro/next/config"
+	chain "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/pkg/env"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	chain.RegisterCloser("client.fault", injectionClient)
+	chain.RegisterCloser("server.fault", injectionServer)
+	chain.RegisterStreamSafe("client.fault", "server.fault")
+}
+
+// DefaultReason is the reason of the injected errors.
+const DefaultReason = "FAULT_INJECTED"
+
+// Rule injects the delay and abort into the matched requests.
+type Rule struct {
+	// Operation is the glob pattern of the operation, empty matches all.
+	Operation string
+	// Headers are the glob patterns of the request header or metadata values.
+	Headers map[string]string
+	Delay   *Delay
+	Abort   *Abort
+}
+
+// Delay delays the request by Fixed, or a random duration in [Fixed, Max] if Max is set.
+type Delay struct {
+	Fixed      time.Duration
+	Max        time.Duration
+	Percentage float64
+}
+
+// Abort fails the request with the kratos error.
+type Abort struct {
+	Code       int
+	Reason     string
+	Message    string
+	Percentage float64
+}
+
+func (r Rule) validate() error {
+	patterns := []string{r.Operation}
+	for _, pattern := range r.Headers {
+		patterns = append(patterns, pattern)
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+	if r.Delay != nil && (r.Delay.Percentage < 0 || r.Delay.Percentage > 100) {
+		return fmt.Errorf("delay percentage must be in [0, 100], got %v", r.Delay.Percentage)
+	}
+	if r.Abort != nil {
+		if r.Abort.Percentage < 0 || r.Abort.Percentage > 100 {
+			return fmt.Errorf("abort percentage must be in [0, 100], got %v", r.Abort.Percentage)
+		}
+		// written as the HTTP status code, net/http panics on the others
+		if r.Abort.Code < 100 || r.Abort.Code > 599 {
+			return fmt.Errorf("abort code must be in [100, 599], got %d", r.Abort.Code)
+		}
+	}
+	return nil
+}
+
+func (r Rule) match(operation string, header transport.Header, md metadata.Metadata) bool {
+	if r.Operation != "" {
+		if matched, _ := path.Match(r.Operation, operation); !matched {
+			return false
+		}
+	}
+	for key, pattern := range r.Headers {
+		var value string
+		if header != nil {
+			value = header.Get(key)
+		}
+		if value == "" {
+			value = md.Get(key)
+		}
+		if matched, _ := path.Match(pattern, value); !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func rulesFromConfig(cfg *v1.Fault) ([]Rule, error) {
+	rules := make([]Rule, 0, len(cfg.GetRules()))
+	for i, r := range cfg.GetRules() {
+		rule := Rule{
+			Operation: r.GetOperation(),
+			Headers:   r.GetHeaders(),
+		}
+		if d := r.GetDelay(); d != nil {
+			rule.Delay = &Delay{
+				Fixed:      d.GetFixed().AsDuration(),
+				Max:        d.GetMax().AsDuration(),
+				Percentage: d.GetPercentage(),
+			}
+		}
+		if a := r.GetAbort(); a != nil {
+			rule.Abort = &Abort{
+				Code:       int(a.GetCode()),
+				Reason:     a.GetReason(),
+				Message:    a.GetMessage(),
+				Percentage: a.GetPercentage(),
+			}
+		}
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("fault: rules[%d]: %v", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Option is fault option.
+type Option func(*Options)
+
+// Options is fault options.
+type Options struct {
+	rules              []Rule
+	watchKey           string
+	enableInProduction bool
+	random             func() float64
+	production         func() bool
+}
+
+// WithRules with the fault rules.
+func WithRules(rules ...Rule) Option {
+	return func(o *Options) {
+		o.rules = rules
+	}
+}
+
+// WithWatch with the config key watched to hot reload the rules.
+func WithWatch(key string) Option {
+	return func(o *Options) {
+		o.watchKey = key
+	}
+}
+
+// WithEnableInProduction injects the faults in production.
+func WithEnableInProduction(enable bool) Option {
+	return func(o *Options) {
+		o.enableInProduction = enable
+	}
+}
+
+func parseConfig(c *config.Middleware) ([]Option, error) {
+	cfg := &v1.Fault{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	rules, err := rulesFromConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return []Option{
+		WithRules(rules...),
+		WithWatch(cfg.GetWatchKey()),
+		WithEnableInProduction(cfg.GetEnableInProduction()),
+	}, nil
+}
+
+func injectionServer(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	opts, err := parseConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	in := newInjector(opts...)
+	return server(in), in.close, nil
+}
+
+func injectionClient(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	opts, err := parseConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	in := newInjector(opts...)
+	return client(in), in.close, nil
+}
+
+// injector holds the rules, they are replaced as a whole on reload.
+type injector struct {
+	rules       atomic.Pointer[[]Rule]
+	random      func() float64
+	production  func() bool
+	unsubscribe func()
+}
+
+func newInjector(opts ...Option) *injector {
+	o := &Options{
+		random:     rand.Float64,
+		production: env.IsProduction,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	in := &injector{random: o.random, production: o.production}
+	rules := o.rules
+	if in.production() && !o.enableInProduction {
+		log.Warnf("fault: disabled in production")
+		rules = nil
+	}
+	in.rules.Store(&rules)
+	if o.watchKey != "" {
+		in.watch(o.watchKey)
+	}
+	return in
+}
+
+// inject delays or aborts the request by the first matched rule.
+func (in *injector) inject(ctx context.Context, operation string, header transport.Header, md metadata.Metadata) error {
+	for _, r := range *in.rules.Load() {
+		if !r.match(operation, header, md) {
+			continue
+		}
+		if d := r.Delay; d != nil && in.hit(d.Percentage) {
+			delay := d.Fixed
+			if d.Max > d.Fixed {
+				delay += time.Duration(in.random() * float64(d.Max-d.Fixed))
+			}
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+		if a := r.Abort; a != nil && in.hit(a.Percentage) {
+			reason := a.Reason
+			if reason == "" {
+				reason = DefaultReason
+			}
+			return kerrors.New(a.Code, reason, a.Message)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (in *injector) hit(percentage float64) bool {
+	return percentage > 0 && in.random()*100 < percentage
+}
+
+// watch reloads the rules when the config of key changes, the invalid rules are ignored.
+func (in *injector) watch(key string) {
+	if conf.DefaultConfig == nil {
+		log.Warnf("fault: config is not loaded, skip watching [%s]", key)
+		return
+	}
+
+	var err error
+	in.unsubscribe, err = conf.Subscribe(key, func(_ string, value kconfig.Value) {
+		cfg := &v1.Fault{}
+		if err := value.Scan(cfg); err != nil {
+			log.Errorf("fault: watch [%s] scan error: %v", key, err)
+			return
+		}
+		rules, err := rulesFromConfig(cfg)
+		if err != nil {
+			log.Errorf("fault: watch [%s] invalid rules, keep the old rules: %v", key, err)
+			return
+		}
+		if in.production() && !cfg.GetEnableInProduction() {
+			rules = nil
+		}
+		in.rules.Store(&rules)
+		log.Infof("fault: watch [%s] reloaded rules: %d", key, len(rules))
+	})
+	if err != nil && !errors.Is(err, kconfig.ErrNotFound) {
+		log.Errorf("fault: watch [%s] error: %v", key, err)
+	}
+}
+
+// close stops watching the rules.
+func (in *injector) close() error {
+	if in.unsubscribe != nil {
+		in.unsubscribe()
+	}
+	return nil
+}
+
+// Server injects the faults into the server requests.
+func Server(opts ...Option) middleware.Middleware {
+	return server(newInjector(opts...))
+}
+
+func server(in *injector) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				md, _ := metadata.FromServerContext(ctx)
+				if err := in.inject(ctx, tr.Operation(), tr.RequestHeader(), md); err != nil {
+					return nil, err
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Client injects the faults into the client requests.
+func Client(opts ...Option) middleware.Middleware {
+	return client(newInjector(opts...))
+}
+
+func client(in *injector) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if tr, ok := transport.FromClientContext(ctx); ok {
+				md, _ := metadata.FromClientContext(ctx)
+				if err := in.inject(ctx, tr.Operation(), tr.RequestHeader(), md); err != nil {
+					return nil, err
+				}
+			}
+			return handler(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package fault
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/transport"
+	conf "github.com/nextmicro/next/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type Transport struct {
+	transport.Transporter
+	operation string
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindGRPC
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+func (tr *Transport) RequestHeader() transport.Header {
+	return nil
+}
+
+func newContext(operation string, md metadata.Metadata) context.Context {
+	ctx := transport.NewServerContext(context.Background(), &Transport{operation: operation})
+	return metadata.NewServerContext(ctx, md)
+}
+
+func handler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func random(v float64) Option {
+	return func(o *Options) {
+		o.random = func() float64 { return v }
+	}
+}
+
+func production(p bool) Option {
+	return func(o *Options) {
+		o.production = func() bool { return p }
+	}
+}
+
+func TestServer(t *testing.T) {
+	h := Server(
+		WithRules(
+			Rule{
+				Operation: "/helloworld.Greeter/*",
+				Headers:   map[string]string{"x-md-global-chaos": "abort"},
+				Abort:     &Abort{Code: 503, Percentage: 50},
+			},
+			Rule{
+				Operation: "/helloworld.Greeter/*",
+				Delay:     &Delay{Fixed: 10 * time.Millisecond, Max: 30 * time.Millisecond, Percentage: 100},
+			},
+		),
+		random(0.4),
+	)(handler)
+
+	_, err := h(newContext("/helloworld.Greeter/SayHello", metadata.New(map[string][]string{"x-md-global-chaos": {"abort"}})), nil)
+	assert.Equal(t, 503, errors.Code(err))
+	assert.Equal(t, DefaultReason, errors.Reason(err))
+
+	start := time.Now()
+	_, err = h(newContext("/helloworld.Greeter/SayHello", nil), nil)
+	assert.NoError(t, err)
+	// 10ms + 0.4 * 20ms
+	assert.GreaterOrEqual(t, time.Since(start), 18*time.Millisecond)
+
+	start = time.Now()
+	_, err = h(newContext("/admin.Admin/List", nil), nil)
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+
+	// not hit
+	h = Server(WithRules(Rule{Abort: &Abort{Code: 500, Percentage: 30}}), random(0.4))(handler)
+	_, err = h(newContext("/helloworld.Greeter/SayHello", nil), nil)
+	assert.NoError(t, err)
+}
+
+func TestServerDelayCanceled(t *testing.T) {
+	h := Server(WithRules(Rule{Delay: &Delay{Fixed: time.Second, Percentage: 100}}))(handler)
+	ctx, cancel := context.WithTimeout(newContext("/test", nil), 10*time.Millisecond)
+	defer cancel()
+	_, err := h(ctx, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestProduction(t *testing.T) {
+	rule := Rule{Abort: &Abort{Code: 500, Percentage: 100}}
+
+	_, err := Server(WithRules(rule), production(true))(handler)(newContext("/test", nil), nil)
+	assert.NoError(t, err)
+
+	_, err = Server(WithRules(rule), production(true), WithEnableInProduction(true))(handler)(newContext("/test", nil), nil)
+	assert.Equal(t, 500, errors.Code(err))
+}
+
+func TestRuleValidate(t *testing.T) {
+	assert.NoError(t, Rule{Abort: &Abort{Code: 503}}.validate())
+	for _, code := range []int{0, 1, 99, 600, 1000} {
+		assert.Error(t, Rule{Abort: &Abort{Code: code}}.validate(), code)
+	}
+	assert.Error(t, Rule{Abort: &Abort{Code: 503, Percentage: 101}}.validate())
+	assert.Error(t, Rule{Operation: "["}.validate())
+}
+
+func TestServerWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write(`
+fault:
+  rules: []
+`)
+	c := kconfig.New(kconfig.WithSource(file.NewSource(path)))
+	assert.NoError(t, c.Load())
+	defer c.Close()
+	old := conf.DefaultConfig
+	conf.DefaultConfig = c
+	defer func() { conf.DefaultConfig = old }()
+
+	h := Server(WithWatch("fault"), production(false))(handler)
+	_, err := h(newContext("/test", nil), nil)
+	assert.NoError(t, err)
+
+	write(`
+fault:
+  rules:
+    - operation: /test
+      abort:
+        code: 429
+        percentage: 100
+`)
+	assert.Eventually(t, func() bool {
+		_, err = h(newContext("/test", nil), nil)
+		return errors.Code(err) == 429
+	}, 3*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	log "github.com/nextmicro/logger"
+	configv1 "github.com/nextmicro/next/api/config/v1"
+)
+
+var globalFilterRegistry = NewFilterRegistry()
+
+// FilterFactory is a HTTP filter factory.
+// Filters wrap the whole HTTP handler and run before routing,
+// e.g. CORS preflight requests must be answered before mux rejects them.
+type FilterFactory func(*configv1.Middleware) (func(http.Handler) http.Handler, error)
+
+// FilterRegistry is the interface for callers to get registered HTTP filters.
+type FilterRegistry interface {
+	Register(name string, factory FilterFactory)
+	Create(cfg *configv1.Middleware) (func(http.Handler) http.Handler, error)
+	Has(name string) bool
+}
+
+type filterRegistry struct {
+	lock   sync.RWMutex
+	filter map[string]FilterFactory
+}
+
+// NewFilterRegistry returns a new HTTP filter registry.
+func NewFilterRegistry() FilterRegistry {
+	return &filterRegistry{
+		filter: map[string]FilterFactory{},
+	}
+}
+
+// Register registers one HTTP filter.
+func (p *filterRegistry) Register(name string, factory FilterFactory) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.filter[createFullName(name)] = factory
+}
+
+// Create instantiates a HTTP filter based on `cfg`.
+func (p *filterRegistry) Create(cfg *configv1.Middleware) (func(http.Handler) http.Handler, error) {
+	p.lock.RLock()
+	factory, ok := p.filter[createFullName(cfg.Name)]
+	p.lock.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return factory(cfg)
+}
+
+// Has reports whether a HTTP filter is registered with name.
+func (p *filterRegistry) Has(name string) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, ok := p.filter[createFullName(name)]
+	return ok
+}
+
+// RegisterFilter registers one HTTP filter.
+func RegisterFilter(name string, factory FilterFactory) {
+	globalFilterRegistry.Register(name, factory)
+}
+
+// CreateFilter instantiates a HTTP filter based on `cfg`.
+func CreateFilter(cfg *configv1.Middleware) (func(http.Handler) http.Handler, error) {
+	return globalFilterRegistry.Create(cfg)
+}
+
+// HasFilter reports whether a HTTP filter is registered with name.
+func HasFilter(name string) bool {
+	return globalFilterRegistry.Has(name)
+}
+
+// BuildFilter builds the HTTP filters configured in `_ms`, keeping the configured order.
+// Entries without a registered filter are ignored, they are handled by BuildMiddleware.
+func BuildFilter(kind string, _ms []*configv1.Middleware, opts ...BuildOption) (fs []func(http.Handler) http.Handler, err error) {
+	o := &buildOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var errs []error
+	for i, m := range _ms {
+		name, err := registeredName(kind, m.Name)
+		if err != nil || !HasFilter(name) {
+			// reported by BuildMiddleware
+			continue
+		}
+
+		f, err := CreateFilter(&configv1.Middleware{Name: name, Options: m.Options})
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			if o.strict {
+				errs = append(errs, fmt.Errorf("%s middlewares[%d] %s: %w", kind, i, m.Name, err))
+				continue
+			}
+
+			log.Errorf("register filter: [%s.%s] error: %v", kind, m.Name, err)
+			continue
+		}
+
+		log.Infof("register filter: [%s.%s] success", kind, m.Name)
+
+		fs = append(fs, f)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return fs, nil
+}
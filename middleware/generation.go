@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+)
+
+// Generation counts the in-flight calls running a built middleware chain,
+// the middlewares replaced by a reload are closed once the calls finish.
+type Generation struct {
+	// refs is the in-flight calls, plus one until the generation is retired.
+	refs    atomic.Int64
+	once    sync.Once
+	closeFn func()
+}
+
+// NewGeneration returns the generation of a new built chain.
+func NewGeneration() *Generation {
+	g := &Generation{}
+	g.refs.Store(1)
+	return g
+}
+
+// Middleware counts the unary calls, it must be the first middleware of the chain.
+func (g *Generation) Middleware() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			g.refs.Add(1)
+			defer g.release()
+			return handler(ctx, req)
+		}
+	}
+}
+
+// StreamMiddleware counts the streams, it must be the first stream middleware of the chain.
+func (g *Generation) StreamMiddleware() StreamMiddleware {
+	return func(handler StreamHandler) StreamHandler {
+		return func(ctx context.Context, stream Stream) error {
+			g.refs.Add(1)
+			defer g.release()
+			return handler(ctx, stream)
+		}
+	}
+}
+
+// Retire calls closeFn once the in-flight calls of the replaced chain finish.
+func (g *Generation) Retire(closeFn func()) {
+	g.closeFn = closeFn
+	g.release()
+}
+
+func (g *Generation) release() {
+	if g.refs.Add(-1) == 0 {
+		g.once.Do(g.closeFn)
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneration(t *testing.T) {
+	var closed int
+	g := NewGeneration()
+	release := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = g.Middleware()(func(context.Context, interface{}) (interface{}, error) {
+			<-release
+			return nil, nil
+		})(context.Background(), nil)
+	}()
+	assert.Eventually(t, func() bool { return g.refs.Load() == 2 }, time.Second, time.Millisecond)
+
+	g.Retire(func() { closed++ })
+	assert.Equal(t, 0, closed)
+	close(release)
+	<-done
+	assert.Equal(t, 1, closed)
+
+	// the late call does not close again
+	_ = g.StreamMiddleware()(func(context.Context, Stream) error { return nil })(context.Background(), nil)
+	assert.Equal(t, 1, closed)
+}
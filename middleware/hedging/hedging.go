@@ -0,0 +1,239 @@
+package hedging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/hedging/v1"
+	chain "github.com/nextmicro/next/middleware"
+	metric "github.com/nextmicro/next/pkg/metrics"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
+	"golang.org/x/time/rate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	// calls the handler concurrently, it is not adapted to streams
+	chain.Register("client.hedging", injection)
+}
+
+func injection(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Hedging{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := make([]Option, 0, 4)
+	if options.GetDelay() != nil {
+		opts = append(opts, WithDelay(options.GetDelay().AsDuration()))
+	}
+	if p := options.GetPercentile(); p != 0 {
+		if p <= 0 || p >= 1 {
+			return nil, fmt.Errorf("hedging: percentile must be in (0, 1), got %v", p)
+		}
+		minSamples := int(options.GetMinSamples())
+		if minSamples <= 0 {
+			minSamples = 100
+		}
+		opts = append(opts, WithPercentile(p, minSamples))
+	}
+	if options.GetMaxPerSecond() > 0 {
+		opts = append(opts, WithMaxPerSecond(int(options.GetMaxPerSecond())))
+	}
+	if len(options.GetOperations()) > 0 {
+		for _, pattern := range options.GetOperations() {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("hedging: invalid operation %q: %v", pattern, err)
+			}
+		}
+		opts = append(opts, WithOperations(options.GetOperations()...))
+	}
+
+	return Client(opts...), nil
+}
+
+// Option is hedging option.
+type Option func(*Options)
+
+// Options is hedging options.
+type Options struct {
+	delay      time.Duration
+	percentile *percentile
+	operations []string
+	limiter    *rate.Limiter
+}
+
+// WithDelay with the fixed hedge delay.
+func WithDelay(delay time.Duration) Option {
+	return func(o *Options) {
+		o.delay = delay
+	}
+}
+
+// WithPercentile with the hedge delay learned from the client duration histogram,
+// the fixed delay is used until `minSamples` requests are observed.
+func WithPercentile(p float64, minSamples int) Option {
+	return func(o *Options) {
+		o.percentile = newPercentile(metric.ClientMetricMillisecond, p, uint64(minSamples))
+	}
+}
+
+// WithMaxPerSecond with the cap on hedges per second.
+func WithMaxPerSecond(n int) Option {
+	return func(o *Options) {
+		o.limiter = rate.NewLimiter(rate.Limit(n), n)
+	}
+}
+
+// WithOperations with the glob patterns of the idempotent operations hedged.
+func WithOperations(patterns ...string) Option {
+	return func(o *Options) {
+		o.operations = patterns
+	}
+}
+
+// Client sends a hedge request to another node when the first has not answered within the delay,
+// the first successful reply wins and the other call is canceled.
+// Only the idempotent calls are hedged, the same as retry: the operations matching WithOperations
+// or the idempotent HTTP methods.
+// The handler is called concurrently, so hedging should be the last client middleware.
+func Client(opts ...Option) middleware.Middleware {
+	options := Options{
+		delay:   100 * time.Millisecond,
+		limiter: rate.NewLimiter(10, 10),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok || !options.isIdempotent(tr) {
+				return handler(ctx, req)
+			}
+			return options.hedge(ctx, req, handler, options.delayOf(tr.Kind().String(), tr.Operation()))
+		}
+	}
+}
+
+type result struct {
+	reply interface{}
+	err   error
+	node  selector.Node
+}
+
+func (o *Options) hedge(ctx context.Context, req interface{}, handler middleware.Handler, delay time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the hedge request excludes the node selected by the first
+	var (
+		mu    sync.Mutex
+		tried []string
+	)
+	outer, _ := selector.FromPeerContext(ctx)
+	ctx = chain.NewReplyContext(ctx)
+	ctx = nodeselector.NewFilterContext(ctx, func(_ context.Context, nodes []selector.Node) []selector.Node {
+		mu.Lock()
+		defer mu.Unlock()
+		return exclude(nodes, tried)
+	})
+	ctx = nodeselector.NewSelectedContext(ctx, func(node selector.Node) error {
+		mu.Lock()
+		tried = append(tried, node.Address())
+		mu.Unlock()
+		return nil
+	})
+
+	results := make(chan result, 2)
+	call := func() {
+		var p selector.Peer
+		reply, err := handler(selector.NewPeerContext(ctx, &p), req)
+		results <- result{reply: reply, err: err, node: p.Node}
+	}
+	go call()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	pending, hedged := 1, false
+	for {
+		select {
+		case <-timer.C:
+			if !hedged && o.limiter.Allow() {
+				hedged = true
+				pending++
+				go call()
+			}
+		case r := <-results:
+			pending--
+			if r.err == nil || pending == 0 {
+				if outer != nil && r.node != nil {
+					outer.Node = r.node
+				}
+				return r.reply, r.err
+			}
+		}
+	}
+}
+
+// isIdempotent reports whether the operation matches the operation patterns,
+// or the http request method is idempotent.
+func (o *Options) isIdempotent(tr transport.Transporter) bool {
+	for _, pattern := range o.operations {
+		if matched, _ := path.Match(pattern, tr.Operation()); matched {
+			return true
+		}
+	}
+	if ht, ok := tr.(interface{ Request() *http.Request }); ok && ht.Request() != nil {
+		switch ht.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Options) delayOf(kind, operation string) time.Duration {
+	if o.percentile != nil {
+		if delay, ok := o.percentile.delay(kind, operation); ok && delay > 0 {
+			return delay
+		}
+	}
+	return o.delay
+}
+
+// exclude returns the nodes not tried, all the nodes if all are tried.
+func exclude(nodes []selector.Node, tried []string) []selector.Node {
+	if len(tried) == 0 {
+		return nodes
+	}
+	out := make([]selector.Node, 0, len(nodes))
+	for _, node := range nodes {
+		found := false
+		for _, address := range tried {
+			if node.Address() == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, node)
+		}
+	}
+	if len(out) == 0 {
+		return nodes
+	}
+	return out
+}
@@ -0,0 +1,196 @@
+package hedging
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	chain "github.com/nextmicro/next/middleware"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type Transport struct {
+	transport.Transporter
+	operation string
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindGRPC
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+var nodes = []selector.Node{
+	selector.NewNode("grpc", "127.0.0.1:9000", &registry.ServiceInstance{}),
+	selector.NewNode("grpc", "127.0.0.1:9001", &registry.ServiceInstance{}),
+}
+
+// slowHandler selects the first node left by the filters, the first node is slow.
+func slowHandler(canceled *atomic.Bool) func(context.Context, interface{}) (interface{}, error) {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		candidates := nodes
+		for _, filter := range nodeselector.FiltersFromContext(ctx) {
+			candidates = filter(ctx, candidates)
+		}
+		node := candidates[0]
+		nodeselector.Selected(ctx, node)
+		p, _ := selector.FromPeerContext(ctx)
+		p.Node = node
+
+		if !chain.IsNewReply(ctx) {
+			panic("expect new reply")
+		}
+		if node.Address() == "127.0.0.1:9000" {
+			select {
+			case <-ctx.Done():
+				canceled.Store(true)
+				return nil, ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+		return node.Address(), nil
+	}
+}
+
+func newContext() context.Context {
+	ctx := transport.NewClientContext(context.Background(), &Transport{operation: "/helloworld.Greeter/SayHello"})
+	return selector.NewPeerContext(ctx, &selector.Peer{})
+}
+
+func TestClient(t *testing.T) {
+	var canceled atomic.Bool
+	m := Client(WithDelay(10*time.Millisecond), WithOperations("/helloworld.Greeter/*"))
+
+	ctx := newContext()
+	start := time.Now()
+	reply, err := m(slowHandler(&canceled))(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9001", reply)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	p, _ := selector.FromPeerContext(ctx)
+	assert.Equal(t, "127.0.0.1:9001", p.Node.Address())
+
+	// the slow call is canceled
+	assert.Eventually(t, canceled.Load, time.Second, time.Millisecond)
+
+	notHedged := func(ctx context.Context, req interface{}) (interface{}, error) {
+		if chain.IsNewReply(ctx) {
+			t.Error("unexpected hedging")
+		}
+		return nil, nil
+	}
+	// not hedged operation
+	m = Client(WithDelay(10*time.Millisecond), WithOperations("/helloworld.Greeter/List*"))
+	_, err = m(notHedged)(newContext(), nil)
+	assert.NoError(t, err)
+
+	// the operations are not idempotent by default
+	m = Client(WithDelay(10 * time.Millisecond))
+	_, err = m(notHedged)(newContext(), nil)
+	assert.NoError(t, err)
+	req, _ := http.NewRequest(http.MethodPost, "/hello", nil)
+	ctx = transport.NewClientContext(context.Background(), &httpTransport{request: req})
+	_, err = m(notHedged)(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+	assert.NoError(t, err)
+
+	// the idempotent http method
+	req, _ = http.NewRequest(http.MethodGet, "/hello", nil)
+	ctx = transport.NewClientContext(context.Background(), &httpTransport{request: req})
+	reply, err = m(slowHandler(&canceled))(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:9001", reply)
+}
+
+type httpTransport struct {
+	transport.Transporter
+	request *http.Request
+}
+
+func (tr *httpTransport) Kind() transport.Kind {
+	return transport.KindHTTP
+}
+
+func (tr *httpTransport) Operation() string {
+	return tr.request.URL.Path
+}
+
+func (tr *httpTransport) Request() *http.Request {
+	return tr.request
+}
+
+func TestStream(t *testing.T) {
+	entries, err := chain.BuildStream("grpc.client", []*config.Middleware{{Name: "hedging"}}, chain.Strict(true))
+	assert.NoError(t, err)
+	// the concurrent calls would race on the stream
+	assert.Empty(t, entries)
+}
+
+func TestClientMaxPerSecond(t *testing.T) {
+	var canceled atomic.Bool
+	m := Client(WithDelay(10*time.Millisecond), WithMaxPerSecond(1), WithOperations("/helloworld.Greeter/*"))
+	h := m(slowHandler(&canceled))
+
+	var (
+		wg      sync.WaitGroup
+		replies = make([]interface{}, 2)
+	)
+	for i := range replies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			replies[i], _ = h(newContext(), nil)
+		}(i)
+	}
+	wg.Wait()
+
+	// only one call is hedged, the other waits for the slow node
+	var hedged int
+	for _, reply := range replies {
+		if reply == "127.0.0.1:9001" {
+			hedged++
+		}
+	}
+	assert.Equal(t, 1, hedged)
+}
+
+func TestPercentile(t *testing.T) {
+	histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "duration_ms",
+		Buckets: []float64{10, 20, 50, 100},
+	}, []string{"kind", "callee", "method"})
+	p := newPercentile(histogram, 0.9, 10)
+
+	_, ok := p.delay("grpc", "/a")
+	assert.False(t, ok)
+
+	// 80 requests in (0, 10], 20 requests in (20, 50] from two callees
+	for i := 0; i < 80; i++ {
+		histogram.WithLabelValues("grpc", "greeter", "/a").Observe(5)
+	}
+	for i := 0; i < 10; i++ {
+		histogram.WithLabelValues("grpc", "greeter", "/a").Observe(30)
+		histogram.WithLabelValues("grpc", "other", "/a").Observe(30)
+	}
+	histogram.WithLabelValues("grpc", "greeter", "/b").Observe(100)
+
+	// the result is cached
+	_, ok = p.delay("grpc", "/a")
+	assert.False(t, ok)
+
+	p.cache = make(map[string]learned)
+	delay, ok := p.delay("grpc", "/a")
+	assert.True(t, ok)
+	// 90th of 100 is the middle of (20, 50]
+	assert.Equal(t, 35*time.Millisecond, delay)
+}
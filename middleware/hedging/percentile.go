@@ -0,0 +1,121 @@
+package hedging
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// percentileTTL is how long the learned delay of an operation is cached.
+var percentileTTL = 10 * time.Second
+
+type learned struct {
+	delay time.Duration
+	ok    bool
+	at    time.Time
+}
+
+// percentile learns the hedge delay from the client duration histogram (ms),
+// the series of all the callees of the operation are merged.
+type percentile struct {
+	histogram  prometheus.Collector
+	percentile float64
+	minSamples uint64
+
+	mu    sync.Mutex
+	cache map[string]learned
+}
+
+func newPercentile(histogram prometheus.Collector, p float64, minSamples uint64) *percentile {
+	return &percentile{
+		histogram:  histogram,
+		percentile: p,
+		minSamples: minSamples,
+		cache:      make(map[string]learned),
+	}
+}
+
+// delay returns the learned delay, false if the requests observed are not enough.
+func (p *percentile) delay(kind, operation string) (time.Duration, bool) {
+	key := kind + " " + operation
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if l, ok := p.cache[key]; ok && time.Since(l.at) < percentileTTL {
+		return l.delay, l.ok
+	}
+
+	d, ok := p.compute(kind, operation)
+	p.cache[key] = learned{delay: d, ok: ok, at: time.Now()}
+	return d, ok
+}
+
+func (p *percentile) compute(kind, operation string) (time.Duration, bool) {
+	ch := make(chan prometheus.Metric, 16)
+	go func() {
+		p.histogram.Collect(ch)
+		close(ch)
+	}()
+
+	var (
+		total   uint64
+		buckets = make(map[float64]uint64)
+	)
+	for m := range ch {
+		var out dto.Metric
+		if err := m.Write(&out); err != nil || out.GetHistogram() == nil {
+			continue
+		}
+		if label(&out, "kind") != kind || label(&out, "method") != operation {
+			continue
+		}
+		total += out.GetHistogram().GetSampleCount()
+		for _, b := range out.GetHistogram().GetBucket() {
+			buckets[b.GetUpperBound()] += b.GetCumulativeCount()
+		}
+	}
+	if total == 0 || total < p.minSamples {
+		return 0, false
+	}
+	return time.Duration(quantile(p.percentile, total, buckets) * float64(time.Millisecond)), true
+}
+
+// quantile interpolates the quantile in the cumulative buckets, the largest bound if it is beyond the buckets.
+func quantile(q float64, total uint64, buckets map[float64]uint64) float64 {
+	bounds := make([]float64, 0, len(buckets))
+	for bound := range buckets {
+		if !math.IsInf(bound, 1) {
+			bounds = append(bounds, bound)
+		}
+	}
+	if len(bounds) == 0 {
+		return 0
+	}
+	sort.Float64s(bounds)
+
+	target := q * float64(total)
+	var prevBound, prevCount float64
+	for _, bound := range bounds {
+		count := float64(buckets[bound])
+		if count >= target {
+			if count == prevCount {
+				return bound
+			}
+			return prevBound + (bound-prevBound)*(target-prevCount)/(count-prevCount)
+		}
+		prevBound, prevCount = bound, count
+	}
+	return bounds[len(bounds)-1]
+}
+
+func label(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,350 @@
+package hmac
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/hmac/v1"
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	chain.RegisterCloser("client.hmac", injectionClient)
+	chain.RegisterCloser("server.hmac", injectionServer)
+	// authenticates the stream opening, the stream messages are not signed
+	chain.RegisterStreamSafe("client.hmac", "server.hmac")
+}
+
+const (
+	// HeaderKeyID is the header of the key id.
+	HeaderKeyID = "x-sign-key-id"
+	// HeaderTimestamp is the header of the unix timestamp in seconds.
+	HeaderTimestamp = "x-sign-timestamp"
+	// HeaderNonce is the header of the random nonce.
+	HeaderNonce = "x-sign-nonce"
+	// HeaderSignature is the header of the base64 HMAC-SHA256 signature.
+	HeaderSignature = "x-sign-signature"
+
+	reason = "SIGNATURE"
+
+	defaultClockSkew = 5 * time.Minute
+)
+
+var (
+	ErrMissingSignature = errors.Unauthorized(reason, "signature is missing")
+	ErrUnknownKey       = errors.Unauthorized(reason, "signature key is unknown")
+	ErrInvalidTimestamp = errors.Unauthorized(reason, "signature timestamp is out of the clock skew window")
+	ErrReplayed         = errors.Unauthorized(reason, "signature nonce is replayed")
+	ErrInvalidSignature = errors.Unauthorized(reason, "signature is invalid")
+	ErrMissingKey       = errors.Unauthorized(reason, "signing key is missing")
+	ErrWrongContext     = errors.Unauthorized(reason, "Wrong context for middleware")
+)
+
+// Option is hmac option.
+type Option func(*Options)
+
+// Options is hmac options.
+type Options struct {
+	keys      *Keys
+	watchKey  string
+	clockSkew time.Duration
+	allows    []string
+	now       func() time.Time
+	nonces    *nonceCache
+
+	watchOnce   sync.Once
+	mu          sync.Mutex
+	closed      bool
+	unsubscribe func()
+}
+
+// WithKeys with the rotatable keys.
+func WithKeys(keys *Keys) Option {
+	return func(o *Options) {
+		o.keys = keys
+	}
+}
+
+// WithWatch with the config key watched to rotate the keys.
+func WithWatch(key string) Option {
+	return func(o *Options) {
+		o.watchKey = key
+	}
+}
+
+// WithClockSkew with the accepted timestamp window, default: 5m.
+func WithClockSkew(skew time.Duration) Option {
+	return func(o *Options) {
+		o.clockSkew = skew
+	}
+}
+
+// WithAllowOperations with the glob patterns of the operations skipping verification.
+func WithAllowOperations(patterns ...string) Option {
+	return func(o *Options) {
+		o.allows = patterns
+	}
+}
+
+func parseConfig(c *config.Middleware) ([]Option, error) {
+	cfg := &v1.HMAC{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+	for _, pattern := range cfg.GetAllowOperations() {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("hmac: allow_operations %q: %v", pattern, err)
+		}
+	}
+
+	opts := []Option{
+		WithKeys(NewKeys(keyringFromConfig(cfg))),
+		WithWatch(cfg.GetWatchKey()),
+		WithAllowOperations(cfg.GetAllowOperations()...),
+	}
+	if cfg.GetClockSkew() != nil {
+		opts = append(opts, WithClockSkew(cfg.GetClockSkew().AsDuration()))
+	}
+	return opts, nil
+}
+
+func injectionClient(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	opts, err := parseConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	o := newOptions(opts...)
+	return client(o), o.close, nil
+}
+
+func injectionServer(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	opts, err := parseConfig(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	o := newOptions(opts...)
+	return server(o), o.close, nil
+}
+
+func newOptions(opts ...Option) *Options {
+	o := &Options{
+		clockSkew: defaultClockSkew,
+		now:       time.Now,
+		nonces:    nonces,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.keys == nil {
+		o.keys = NewKeys(&Keyring{})
+	}
+	return o
+}
+
+// watch starts rotating the keys on the first request signed or verified,
+// the instances never serving a request, e.g. replaced by a reload, do not watch.
+func (o *Options) watch() {
+	if o.watchKey == "" {
+		return
+	}
+	o.watchOnce.Do(func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if !o.closed {
+			o.unsubscribe = o.keys.watch(o.watchKey)
+		}
+	})
+}
+
+// close stops rotating the keys.
+func (o *Options) close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.closed = true
+	if o.unsubscribe != nil {
+		o.unsubscribe()
+		o.unsubscribe = nil
+	}
+	return nil
+}
+
+// Client signs the requests with the key of the key id,
+// it should be the inner of the retry and hedging, the nonce of each attempt is different.
+func Client(opts ...Option) middleware.Middleware {
+	return client(newOptions(opts...))
+}
+
+func client(o *Options) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return nil, ErrWrongContext
+			}
+			o.watch()
+			keyID, secret, ok := o.keys.signing()
+			if !ok {
+				return nil, ErrMissingKey
+			}
+
+			method, path, body, err := requestContent(tr, req)
+			if err != nil {
+				return nil, err
+			}
+			nonce, err := newNonce()
+			if err != nil {
+				return nil, err
+			}
+			timestamp := strconv.FormatInt(o.now().Unix(), 10)
+
+			header := tr.RequestHeader()
+			header.Set(HeaderKeyID, keyID)
+			header.Set(HeaderTimestamp, timestamp)
+			header.Set(HeaderNonce, nonce)
+			header.Set(HeaderSignature, Sign(secret, timestamp, nonce, method, path, body))
+			return handler(ctx, req)
+		}
+	}
+}
+
+// Server verifies the signature of the requests, the replayed nonces
+// and the timestamps out of the clock skew window are rejected.
+// The nonces are remembered in the memory of the process, so a request
+// replayed to another replica within the clock skew window is not rejected.
+// On streams only the opening of the stream is verified, the stream messages are not signed,
+// so they are not protected against tampering by the signature.
+func Server(opts ...Option) middleware.Middleware {
+	return server(newOptions(opts...))
+}
+
+func server(o *Options) middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, ErrWrongContext
+			}
+			if o.allowed(tr.Operation()) {
+				return handler(ctx, req)
+			}
+
+			o.watch()
+			header := tr.RequestHeader()
+			keyID := header.Get(HeaderKeyID)
+			timestamp := header.Get(HeaderTimestamp)
+			nonce := header.Get(HeaderNonce)
+			signature := header.Get(HeaderSignature)
+			if keyID == "" || timestamp == "" || nonce == "" || signature == "" {
+				return nil, ErrMissingSignature
+			}
+			secret, ok := o.keys.secret(keyID)
+			if !ok {
+				return nil, ErrUnknownKey
+			}
+			sec, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				return nil, ErrInvalidTimestamp
+			}
+			now := o.now()
+			if d := now.Sub(time.Unix(sec, 0)); d > o.clockSkew || d < -o.clockSkew {
+				return nil, ErrInvalidTimestamp
+			}
+
+			method, path, body, err := requestContent(tr, req)
+			if err != nil {
+				return nil, err
+			}
+			expected := Sign(secret, timestamp, nonce, method, path, body)
+			if !hmac.Equal([]byte(expected), []byte(signature)) {
+				return nil, ErrInvalidSignature
+			}
+			// only the verified nonces are remembered
+			if !o.nonces.add(keyID+":"+nonce, now, 2*o.clockSkew) {
+				return nil, ErrReplayed
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func (o *Options) allowed(operation string) bool {
+	for _, pattern := range o.allows {
+		if matched, _ := path.Match(pattern, operation); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the base64 HMAC-SHA256 signature of:
+//
+//	timestamp \n nonce \n method \n path \n hex(sha256(body))
+func Sign(secret []byte, timestamp, nonce, method, path string, body []byte) string {
+	sum := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join([]string{timestamp, nonce, method, path, hex.EncodeToString(sum[:])}, "\n")))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// requestContent returns the signed content of the request,
+// the raw body of HTTP, or the deterministic proto encoding of the gRPC request.
+// The request of a stream is nil, the stream messages are not part of the signed content.
+func requestContent(tr transport.Transporter, req interface{}) (method, path string, body []byte, err error) {
+	if ht, ok := tr.(interface{ Request() *http.Request }); ok && ht.Request() != nil {
+		r := ht.Request()
+		body, err = readBody(r)
+		return r.Method, r.URL.Path, body, err
+	}
+
+	if m, ok := req.(proto.Message); ok {
+		body, err = proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	}
+	return http.MethodPost, tr.Operation(), body, err
+}
+
+// readBody reads the body and resets it for the following readers.
+func readBody(r *http.Request) ([]byte, error) {
+	if r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewBuffer(data))
+	return data, err
+}
+
+func newNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,232 @@
+package hmac
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	conf "github.com/nextmicro/next/config"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range http.Header(hc) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type Transport struct {
+	transport.Transporter
+	operation string
+	header    headerCarrier
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindGRPC
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+func (tr *Transport) RequestHeader() transport.Header {
+	return tr.header
+}
+
+type httpTransport struct {
+	Transport
+	request *http.Request
+}
+
+func (tr *httpTransport) Kind() transport.Kind {
+	return transport.KindHTTP
+}
+
+func (tr *httpTransport) Request() *http.Request {
+	return tr.request
+}
+
+func handler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+// sign sets the signature headers of tr by the client.
+func sign(t *testing.T, client Option, tr transport.Transporter, req interface{}) {
+	_, err := Client(client)(handler)(transport.NewClientContext(context.Background(), tr), req)
+	assert.NoError(t, err)
+}
+
+func TestGRPC(t *testing.T) {
+	keys := NewKeys(&Keyring{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("secret")}})
+	h := Server(WithKeys(keys), WithAllowOperations("/grpc.health.v1.Health/*"))(handler)
+	verify := func(operation string, header headerCarrier, req interface{}) error {
+		ctx := transport.NewServerContext(context.Background(), &Transport{operation: operation, header: header})
+		_, err := h(ctx, req)
+		return err
+	}
+
+	header := headerCarrier{}
+	req := wrapperspb.String("hello")
+	sign(t, WithKeys(keys), &Transport{operation: "/helloworld.Greeter/SayHello", header: header}, req)
+	assert.NoError(t, verify("/helloworld.Greeter/SayHello", header, req))
+
+	// replayed
+	assert.True(t, errors.Is(verify("/helloworld.Greeter/SayHello", header, req), ErrReplayed))
+
+	// tampered
+	header = headerCarrier{}
+	sign(t, WithKeys(keys), &Transport{operation: "/helloworld.Greeter/SayHello", header: header}, req)
+	assert.True(t, errors.Is(verify("/helloworld.Greeter/SayHello", header, wrapperspb.String("world")), ErrInvalidSignature))
+	assert.True(t, errors.Is(verify("/helloworld.Greeter/SayBye", header, req), ErrInvalidSignature))
+
+	assert.True(t, errors.Is(verify("/helloworld.Greeter/SayHello", headerCarrier{}, req), ErrMissingSignature))
+	assert.NoError(t, verify("/grpc.health.v1.Health/Check", headerCarrier{}, req))
+}
+
+func TestHTTP(t *testing.T) {
+	keys := NewKeys(&Keyring{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("secret")}})
+
+	body := []byte(`{"name":"next"}`)
+	clientReq, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1/v1/hello", bytes.NewReader(body))
+	sign(t, WithKeys(keys), &httpTransport{
+		Transport: Transport{operation: "/helloworld.Greeter/SayHello", header: headerCarrier(clientReq.Header)},
+		request:   clientReq,
+	}, nil)
+
+	serverReq, _ := http.NewRequest(http.MethodPost, "http://127.0.0.1/v1/hello", bytes.NewReader(body))
+	serverReq.Header = clientReq.Header.Clone()
+	ctx := transport.NewServerContext(context.Background(), &httpTransport{
+		Transport: Transport{operation: "/helloworld.Greeter/SayHello", header: headerCarrier(serverReq.Header)},
+		request:   serverReq,
+	})
+	_, err := Server(WithKeys(keys))(handler)(ctx, nil)
+	assert.NoError(t, err)
+
+	// the body is kept for the handler
+	data, _ := io.ReadAll(serverReq.Body)
+	assert.Equal(t, body, data)
+}
+
+func TestServerClockSkew(t *testing.T) {
+	keys := NewKeys(&Keyring{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("secret")}})
+	client := func(o *Options) {
+		o.keys = keys
+		o.now = func() time.Time { return time.Now().Add(-time.Minute) }
+	}
+
+	header := headerCarrier{}
+	sign(t, client, &Transport{operation: "/test", header: header}, nil)
+	ctx := transport.NewServerContext(context.Background(), &Transport{operation: "/test", header: header})
+	_, err := Server(WithKeys(keys), WithClockSkew(30*time.Second))(handler)(ctx, nil)
+	assert.True(t, errors.Is(err, ErrInvalidTimestamp))
+	_, err = Server(WithKeys(keys), WithClockSkew(2*time.Minute))(handler)(ctx, nil)
+	assert.NoError(t, err)
+}
+
+func TestKeysRotate(t *testing.T) {
+	keys := NewKeys(&Keyring{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("secret1")}})
+	h := Server(WithKeys(keys))(handler)
+
+	old := headerCarrier{}
+	sign(t, WithKeys(keys), &Transport{operation: "/test", header: old}, nil)
+
+	// the new key signs, the old key is still accepted
+	keys.Rotate(&Keyring{KeyID: "k2", Keys: map[string][]byte{"k1": []byte("secret1"), "k2": []byte("secret2")}})
+	_, err := h(transport.NewServerContext(context.Background(), &Transport{operation: "/test", header: old}), nil)
+	assert.NoError(t, err)
+
+	header := headerCarrier{}
+	sign(t, WithKeys(keys), &Transport{operation: "/test", header: header}, nil)
+	assert.Equal(t, "k2", header.Get(HeaderKeyID))
+	_, err = h(transport.NewServerContext(context.Background(), &Transport{operation: "/test", header: header}), nil)
+	assert.NoError(t, err)
+
+	keys.Rotate(&Keyring{KeyID: "k2", Keys: map[string][]byte{"k2": []byte("secret2")}})
+	_, err = h(transport.NewServerContext(context.Background(), &Transport{operation: "/test", header: old}), nil)
+	assert.True(t, errors.Is(err, ErrUnknownKey))
+}
+
+func TestNonceCache(t *testing.T) {
+	c := newNonceCache()
+	now := time.Now()
+	assert.True(t, c.add("n1", now, time.Minute))
+	assert.False(t, c.add("n1", now.Add(30*time.Second), time.Minute))
+	assert.True(t, c.add("n2", now.Add(30*time.Second), time.Minute))
+	// expired and swept
+	assert.True(t, c.add("n1", now.Add(2*time.Minute), time.Minute))
+	assert.Len(t, c.nonces, 1)
+}
+
+func TestServerReplayAfterReload(t *testing.T) {
+	keys := NewKeys(&Keyring{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("secret")}})
+	header := headerCarrier{}
+	sign(t, WithKeys(keys), &Transport{operation: "/test", header: header}, nil)
+	ctx := transport.NewServerContext(context.Background(), &Transport{operation: "/test", header: header})
+
+	_, err := Server(WithKeys(keys))(handler)(ctx, nil)
+	assert.NoError(t, err)
+	// the server created by a reload remembers the nonces
+	_, err = Server(WithKeys(keys))(handler)(ctx, nil)
+	assert.True(t, errors.Is(err, ErrReplayed))
+}
+
+func TestWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`
+hmac:
+  key_id: k2
+  keys:
+    k2: secret2
+`), 0o600))
+	c := kconfig.New(kconfig.WithSource(file.NewSource(path)))
+	assert.NoError(t, c.Load())
+	defer c.Close()
+	old := conf.DefaultConfig
+	conf.DefaultConfig = c
+	defer func() { conf.DefaultConfig = old }()
+
+	newKeys := func() *Keys {
+		return NewKeys(&Keyring{KeyID: "k1", Keys: map[string][]byte{"k1": []byte("secret1")}})
+	}
+
+	// the watch starts on the first request, with the keys of the current config
+	o := newOptions(WithKeys(newKeys()), WithWatch("hmac"))
+	assert.Nil(t, o.unsubscribe)
+	header := headerCarrier{}
+	_, err := client(o)(handler)(transport.NewClientContext(context.Background(), &Transport{operation: "/test", header: header}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "k2", header.Get(HeaderKeyID))
+	assert.NotNil(t, o.unsubscribe)
+	assert.NoError(t, o.close())
+	assert.Nil(t, o.unsubscribe)
+
+	// the closed instance never watches
+	o = newOptions(WithKeys(newKeys()), WithWatch("hmac"))
+	assert.NoError(t, o.close())
+	o.watch()
+	assert.Nil(t, o.unsubscribe)
+	keyID, _, _ := o.keys.signing()
+	assert.Equal(t, "k1", keyID)
+}
@@ -0,0 +1,93 @@
+package hmac
+
+import (
+	"errors"
+	"sync/atomic"
+
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	log "github.com/nextmicro/logger"
+	v1 "github.com/nextmicro/next/api/middleware/hmac/v1"
+	conf "github.com/nextmicro/next/config"
+)
+
+// Keyring is the signing keys by key id, it is replaced as a whole on rotation.
+type Keyring struct {
+	// KeyID is the key signing the client requests.
+	KeyID string
+	// Keys are the secrets by key id.
+	Keys map[string][]byte
+}
+
+func keyringFromConfig(cfg *v1.HMAC) *Keyring {
+	keys := make(map[string][]byte, len(cfg.GetKeys()))
+	for id, secret := range cfg.GetKeys() {
+		keys[id] = []byte(secret)
+	}
+	return &Keyring{KeyID: cfg.GetKeyId(), Keys: keys}
+}
+
+// Keys is the rotatable keyring.
+type Keys struct {
+	current atomic.Pointer[Keyring]
+}
+
+// NewKeys returns the keys of the keyring.
+func NewKeys(k *Keyring) *Keys {
+	keys := &Keys{}
+	keys.Rotate(k)
+	return keys
+}
+
+// Rotate replaces the keyring.
+func (k *Keys) Rotate(keyring *Keyring) {
+	k.current.Store(keyring)
+}
+
+// signing returns the key id and secret signing the client requests.
+func (k *Keys) signing() (string, []byte, bool) {
+	keyring := k.current.Load()
+	secret, ok := keyring.Keys[keyring.KeyID]
+	return keyring.KeyID, secret, ok
+}
+
+// secret returns the secret of the key id.
+func (k *Keys) secret(id string) ([]byte, bool) {
+	secret, ok := k.current.Load().Keys[id]
+	return secret, ok
+}
+
+// watch rotates the keys to the current config of key and when it changes,
+// it returns the unsubscribe of the watch.
+func (k *Keys) watch(key string) func() {
+	if conf.DefaultConfig == nil {
+		log.Warnf("hmac: config is not loaded, skip watching [%s]", key)
+		return nil
+	}
+
+	unsubscribe, err := conf.Subscribe(key, func(_ string, value kconfig.Value) {
+		k.load(key, value)
+	})
+	if err != nil {
+		if !errors.Is(err, kconfig.ErrNotFound) {
+			log.Errorf("hmac: watch [%s] error: %v", key, err)
+		}
+		return unsubscribe
+	}
+	// the config may have changed since the middleware was created
+	k.load(key, conf.DefaultConfig.Value(key))
+	return unsubscribe
+}
+
+func (k *Keys) load(key string, value kconfig.Value) {
+	cfg := &v1.HMAC{}
+	if err := value.Scan(cfg); err != nil {
+		log.Errorf("hmac: watch [%s] scan error: %v", key, err)
+		return
+	}
+	if len(cfg.GetKeys()) == 0 {
+		log.Errorf("hmac: watch [%s] no keys, keep the old keys", key)
+		return
+	}
+	k.Rotate(keyringFromConfig(cfg))
+	log.Infof("hmac: watch [%s] rotated keys: %d", key, len(cfg.GetKeys()))
+}
@@ -0,0 +1,45 @@
+package hmac
+
+import (
+	"sync"
+	"time"
+)
+
+// nonces is shared by the servers of the process, the nonces are kept when the middlewares are
+// replaced by a reload. They are not shared across the replicas, a request replayed to another
+// replica within the clock skew window is not rejected.
+var nonces = newNonceCache()
+
+// nonceCache remembers the nonces seen in the ttl to reject the replayed requests.
+type nonceCache struct {
+	mu        sync.Mutex
+	nonces    map[string]time.Time
+	lastSweep time.Time
+}
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// add returns false if the nonce has been seen, the nonce is remembered in the ttl.
+func (c *nonceCache) add(nonce string, now time.Time, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.lastSweep) > ttl {
+		for k, expire := range c.nonces {
+			if now.After(expire) {
+				delete(c.nonces, k)
+			}
+		}
+		c.lastSweep = now
+	}
+
+	if expire, ok := c.nonces[nonce]; ok && !now.After(expire) {
+		return false
+	}
+	c.nonces[nonce] = now.Add(ttl)
+	return true
+}
@@ -15,6 +15,7 @@ import (
 	config "github.com/nextmicro/next/api/config/v1"
 	v1 "github.com/nextmicro/next/api/middleware/logging/v1"
 	chain "github.com/nextmicro/next/middleware"
+	identity "github.com/nextmicro/next/pkg/caller"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -29,6 +30,8 @@ const namespace = "logging"
 func init() {
 	chain.Register("client."+namespace, injectionClient)
 	chain.Register("server."+namespace, injectionServer)
+	chain.RegisterStream("client."+namespace, injectionStreamClient)
+	chain.RegisterStream("server."+namespace, injectionStreamServer)
 }
 
 // Redacter defines how to log an object
@@ -99,7 +102,8 @@ func injectMetadata(rules []Metadata, md metadata.Metadata) map[string]string {
 	return fields
 }
 
-func injectionClient(c *config.Middleware) (middleware.Middleware, error) {
+// parseOptions parses the logging options of the middleware config.
+func parseOptions(c *config.Middleware) ([]Option, error) {
 	v := durationpb.New(time.Millisecond * 300)
 	options := &v1.Logging{
 		TimeFormat:    defaultFormat,
@@ -144,6 +148,15 @@ func injectionClient(c *config.Middleware) (middleware.Middleware, error) {
 		opts = append(opts, WithMetadata(_md))
 	}
 
+	return opts, nil
+}
+
+func injectionClient(c *config.Middleware) (middleware.Middleware, error) {
+	opts, err := parseOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
 	return Client(opts...), nil
 }
 
@@ -250,49 +263,9 @@ func Client(opts ...Option) middleware.Middleware {
 }
 
 func injectionServer(c *config.Middleware) (middleware.Middleware, error) {
-	v := durationpb.New(time.Millisecond * 300)
-	options := &v1.Logging{
-		TimeFormat:    defaultFormat,
-		SlowThreshold: v,
-	}
-
-	if c.Options != nil {
-		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
-			return nil, err
-		}
-	}
-
-	opts := make([]Option, 0)
-	if options.Disabled {
-		opts = append(opts, WithDisabled(options.Disabled))
-	}
-	if options.TimeFormat != "" {
-		opts = append(opts, WithTimeFormat(options.TimeFormat))
-	}
-	if options.SlowThreshold != nil && options.SlowThreshold.AsDuration() > 0 {
-		opts = append(opts, WithSlowThreshold(options.SlowThreshold.AsDuration()))
-	}
-	if len(options.IgnoredRoutes) > 0 {
-		opts = append(opts, WithIgnoredRoutes(options.IgnoredRoutes))
-	}
-	if options.AccessLevel != "" {
-		opts = append(opts, WithAccessLevel(logger.ParseLevel(options.AccessLevel)))
-	}
-	if options.DumpRequest {
-		opts = append(opts, WithDumpRequest(options.DumpRequest))
-	}
-	if options.DumpResponse {
-		opts = append(opts, WithDumpResp(options.DumpResponse))
-	}
-	if len(options.Metadata) > 0 {
-		_md := make([]Metadata, 0, len(options.Metadata))
-		for _, md := range options.Metadata {
-			_md = append(_md, Metadata{
-				Key:    md.Key,
-				Rename: md.Rename,
-			})
-		}
-		opts = append(opts, WithMetadata(_md))
+	opts, err := parseOptions(c)
+	if err != nil {
+		return nil, err
 	}
 
 	return Server(opts...), nil
@@ -338,10 +311,8 @@ func Server(opts ...Option) middleware.Middleware {
 				return handler(ctx, req)
 			}
 
-			if md, ok := metadata.FromServerContext(ctx); ok {
-				if v := md.Get("x-md-local-caller"); v != "" {
-					caller = v
-				}
+			if c, ok := identity.FromContext(ctx); ok {
+				caller = c.Name
 			}
 
 			resp, err := handler(ctx, req)
@@ -0,0 +1,215 @@
+package logging
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/nextmicro/gokit/timex"
+	"github.com/nextmicro/logger"
+	config "github.com/nextmicro/next/api/config/v1"
+	chain "github.com/nextmicro/next/middleware"
+	identity "github.com/nextmicro/next/pkg/caller"
+)
+
+func injectionStreamClient(c *config.Middleware) (chain.StreamMiddleware, error) {
+	opts, err := parseOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return StreamClient(opts...), nil
+}
+
+// StreamClient is a client stream logging middleware.
+// One access log is printed when the stream is finished.
+func StreamClient(opts ...Option) chain.StreamMiddleware {
+	cfg := Options{
+		timeFormat:    defaultFormat,        // 默认时间格式
+		logger:        logger.DefaultLogger, // 默认日志
+		accessLevel:   logger.DebugLevel,
+		slowThreshold: time.Millisecond * 300, // 默认慢日志时间
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return func(handler chain.StreamHandler) chain.StreamHandler {
+		return func(ctx context.Context, stream chain.Stream) error {
+			if cfg.disabled {
+				return handler(ctx, stream)
+			}
+
+			var (
+				kind        string
+				route       string
+				callee      = "unknown"
+				startTime   = time.Now()
+				nodeAddress = ""
+				recv, sent  int64
+			)
+
+			if info, ok := transport.FromClientContext(ctx); ok {
+				kind = info.Kind().String()
+				route = info.Operation()
+			}
+
+			// ignore route
+			if matchRoute(route, cfg.ignoredRoutes) {
+				return handler(ctx, stream)
+			}
+
+			err := handler(ctx, countStream(stream, &recv, &sent))
+			duration := time.Since(startTime)
+
+			if peer, ok := selector.FromPeerContext(ctx); ok && peer.Node != nil {
+				callee = peer.Node.ServiceName()
+				nodeAddress = peer.Node.Address()
+			}
+
+			fields := map[string]interface{}{
+				"start":          startTime.Format(cfg.timeFormat),
+				"kind":           "client",
+				"component":      kind,
+				"route":          route,
+				"duration":       timex.Duration(duration),
+				"callee_service": callee,
+				"recv_msgs":      atomic.LoadInt64(&recv),
+				"send_msgs":      atomic.LoadInt64(&sent),
+			}
+			if nodeAddress != "" {
+				fields["callee.address"] = nodeAddress
+			}
+			if v := extractError(err); v != "" {
+				fields["error"] = v
+			}
+			if se := errors.FromError(err); se != nil {
+				fields["code"] = se.Code
+				fields["reason"] = se.Reason
+			}
+
+			// inject metadata
+			if md, ok := metadata.FromClientContext(ctx); ok {
+				fields = mergeFields(fields, injectMetadata(cfg.Metadata, md))
+			}
+
+			_log := logger.WithContext(ctx).WithFields(fields)
+			// streams are long-lived, the duration is not compared with the slow threshold
+			if err != nil {
+				_log.Error(kind + " client stream")
+			} else {
+				_log.Debug(kind + " client stream")
+			}
+
+			return err
+		}
+	}
+}
+
+func injectionStreamServer(c *config.Middleware) (chain.StreamMiddleware, error) {
+	opts, err := parseOptions(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return StreamServer(opts...), nil
+}
+
+// StreamServer is a server stream logging middleware.
+// One access log is printed when the stream is finished.
+func StreamServer(opts ...Option) chain.StreamMiddleware {
+	cfg := Options{
+		timeFormat:    defaultFormat,          // 默认时间格式
+		slowThreshold: time.Millisecond * 300, // 默认慢日志时间
+		logger:        logger.DefaultLogger,   // 默认日志
+		accessLevel:   logger.DebugLevel,
+	}
+	for _, o := range opts {
+		o(&cfg)
+	}
+
+	return func(handler chain.StreamHandler) chain.StreamHandler {
+		return func(ctx context.Context, stream chain.Stream) error {
+			if cfg.disabled {
+				return handler(ctx, stream)
+			}
+
+			var (
+				kind       string
+				route      string
+				caller     = "unknown"
+				startTime  = time.Now()
+				recv, sent int64
+			)
+
+			if info, ok := transport.FromServerContext(ctx); ok {
+				kind = info.Kind().String()
+				route = info.Operation()
+			}
+
+			// ignore route
+			if matchRoute(route, cfg.ignoredRoutes) {
+				return handler(ctx, stream)
+			}
+
+			if c, ok := identity.FromContext(ctx); ok {
+				caller = c.Name
+			}
+
+			err := handler(ctx, countStream(stream, &recv, &sent))
+			duration := time.Since(startTime)
+			fields := map[string]interface{}{
+				"start":          startTime.Format(cfg.timeFormat),
+				"kind":           "server",
+				"component":      kind,
+				"route":          route,
+				"duration":       timex.Duration(duration),
+				"caller_service": caller,
+				"recv_msgs":      atomic.LoadInt64(&recv),
+				"send_msgs":      atomic.LoadInt64(&sent),
+			}
+			if se := errors.FromError(err); se != nil {
+				fields["code"] = se.Code
+				fields["reason"] = se.Reason
+			}
+			if v := extractError(err); v != "" {
+				fields["error"] = v
+			}
+
+			// inject metadata
+			if md, ok := metadata.FromServerContext(ctx); ok {
+				fields = mergeFields(fields, injectMetadata(cfg.Metadata, md))
+			}
+
+			_log := logger.WithContext(ctx).WithFields(fields)
+			// streams are long-lived, the duration is not compared with the slow threshold
+			if err != nil {
+				_log.Error(kind + " server stream")
+			} else {
+				_log.Debug(kind + " server stream")
+			}
+
+			return err
+		}
+	}
+}
+
+// countStream counts the messages successfully received and sent.
+func countStream(stream chain.Stream, recv, sent *int64) chain.Stream {
+	return chain.WrapStream(stream,
+		chain.OnRecvMsg(func(_ interface{}, err error) {
+			if err == nil {
+				atomic.AddInt64(recv, 1)
+			}
+		}),
+		chain.OnSendMsg(func(_ interface{}, err error) {
+			if err == nil {
+				atomic.AddInt64(sent, 1)
+			}
+		}),
+	)
+}
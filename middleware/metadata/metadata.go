@@ -19,6 +19,7 @@ const namespace = "metadata"
 func init() {
 	chain.Register("client."+namespace, injectionClient)
 	chain.Register("server."+namespace, injectionServer)
+	chain.RegisterStreamSafe("client."+namespace, "server."+namespace)
 }
 
 // Option is metadata option.
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	prom "github.com/go-kratos/kratos/contrib/metrics/prometheus/v2"
-	"github.com/go-kratos/kratos/v2/metadata"
 	"github.com/go-kratos/kratos/v2/metrics"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/selector"
@@ -13,6 +12,7 @@ import (
 	config "github.com/nextmicro/next/api/config/v1"
 	v1 "github.com/nextmicro/next/api/middleware/metrics/v1"
 	chain "github.com/nextmicro/next/middleware"
+	identity "github.com/nextmicro/next/pkg/caller"
 	metric "github.com/nextmicro/next/pkg/metrics"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
@@ -23,6 +23,8 @@ const namespace = "metrics"
 func init() {
 	chain.Register("client."+namespace, injectionClient)
 	chain.Register("server."+namespace, injectionServer)
+	chain.RegisterStream("client."+namespace, injectionStreamClient)
+	chain.RegisterStream("server."+namespace, injectionStreamServer)
 }
 
 type Option func(o *Options)
@@ -38,6 +40,9 @@ type Options struct {
 
 	// histogram: <client/server>_requests_seconds_bucket{kind, operation}
 	seconds metrics.Observer
+
+	// counter: <client/server>_stream_messages_total{kind, operation, type}
+	messages metrics.Counter
 }
 
 // WithDisabled set disabled metrics.
@@ -61,6 +66,13 @@ func WithSeconds(c metrics.Observer) Option {
 	}
 }
 
+// WithMessages with stream messages counter.
+func WithMessages(c metrics.Counter) Option {
+	return func(o *Options) {
+		o.messages = c
+	}
+}
+
 func injectionClient(c *config.Middleware) (middleware.Middleware, error) {
 	cfg := &v1.Metrics{}
 	if c.Options != nil {
@@ -146,10 +158,8 @@ func Server(opts ...Option) middleware.Middleware {
 				status string
 			)
 			startTime := time.Now()
-			if md, ok := metadata.FromServerContext(ctx); ok {
-				if v := md.Get("x-md-local-caller"); v != "" {
-					caller = v
-				}
+			if c, ok := identity.FromContext(ctx); ok {
+				caller = c.Name
 			}
 			if info, ok := transport.FromServerContext(ctx); ok {
 				kind = info.Kind().String()
@@ -0,0 +1,147 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	prom "github.com/go-kratos/kratos/contrib/metrics/prometheus/v2"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/metrics/v1"
+	chain "github.com/nextmicro/next/middleware"
+	identity "github.com/nextmicro/next/pkg/caller"
+	metric "github.com/nextmicro/next/pkg/metrics"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func injectionStreamClient(c *config.Middleware) (chain.StreamMiddleware, error) {
+	cfg := &v1.Metrics{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := make([]Option, 0)
+	return StreamClient(opts...), nil
+}
+
+// StreamClient is stream middleware client-side metrics.
+// The duration covers the whole stream, the sent and received messages are counted.
+func StreamClient(opts ...Option) chain.StreamMiddleware {
+	options := Options{
+		requests: prom.NewCounter(metric.ClientMetricRequests),
+		seconds:  prom.NewHistogram(metric.ClientMetricMillisecond),
+		messages: prom.NewCounter(metric.ClientMetricStreamMessages),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return func(handler chain.StreamHandler) chain.StreamHandler {
+		return func(ctx context.Context, stream chain.Stream) error {
+			var (
+				kind   string
+				method string
+				callee = "unknown"
+				recv   int64
+				sent   int64
+			)
+
+			startTime := time.Now()
+			if info, ok := transport.FromClientContext(ctx); ok {
+				kind = info.Kind().String()
+				method = info.Operation()
+			}
+			err := handler(ctx, countStream(stream, &recv, &sent))
+			if peer, ok := selector.FromPeerContext(ctx); ok && peer.Node != nil {
+				callee = peer.Node.ServiceName()
+			}
+			options.observe(err, startTime, atomic.LoadInt64(&recv), atomic.LoadInt64(&sent), kind, callee, method)
+			return err
+		}
+	}
+}
+
+func injectionStreamServer(c *config.Middleware) (chain.StreamMiddleware, error) {
+	cfg := &v1.Metrics{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := make([]Option, 0)
+	return StreamServer(opts...), nil
+}
+
+// StreamServer is stream middleware server-side metrics.
+// The duration covers the whole stream, the sent and received messages are counted.
+func StreamServer(opts ...Option) chain.StreamMiddleware {
+	options := Options{
+		requests: prom.NewCounter(metric.ServerMetricRequests),
+		seconds:  prom.NewHistogram(metric.ServerMetricMillisecond),
+		messages: prom.NewCounter(metric.ServerMetricStreamMessages),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return func(handler chain.StreamHandler) chain.StreamHandler {
+		return func(ctx context.Context, stream chain.Stream) error {
+			var (
+				kind   string
+				method string
+				caller string
+				recv   int64
+				sent   int64
+			)
+
+			startTime := time.Now()
+			if c, ok := identity.FromContext(ctx); ok {
+				caller = c.Name
+			}
+			if info, ok := transport.FromServerContext(ctx); ok {
+				kind = info.Kind().String()
+				method = info.Operation()
+			}
+			err := handler(ctx, countStream(stream, &recv, &sent))
+			options.observe(err, startTime, atomic.LoadInt64(&recv), atomic.LoadInt64(&sent), kind, caller, method)
+			return err
+		}
+	}
+}
+
+// observe reports the finished stream.
+func (o *Options) observe(err error, startTime time.Time, recv, sent int64, kind, peer, method string) {
+	status := metric.FromErrorCode(err).String()
+	if o.requests != nil {
+		o.requests.With(kind, peer, method, status).Inc()
+	}
+	if o.seconds != nil {
+		o.seconds.With(kind, peer, method).Observe(float64(time.Since(startTime).Milliseconds()))
+	}
+	if o.messages != nil {
+		o.messages.With(kind, peer, method, "recv").Add(float64(recv))
+		o.messages.With(kind, peer, method, "send").Add(float64(sent))
+	}
+}
+
+// countStream counts the messages successfully received and sent.
+func countStream(stream chain.Stream, recv, sent *int64) chain.Stream {
+	return chain.WrapStream(stream,
+		chain.OnRecvMsg(func(_ interface{}, err error) {
+			if err == nil {
+				atomic.AddInt64(recv, 1)
+			}
+		}),
+		chain.OnSendMsg(func(_ interface{}, err error) {
+			if err == nil {
+				atomic.AddInt64(sent, 1)
+			}
+		}),
+	)
+}
@@ -3,9 +3,9 @@ package middleware
 import (
 	"errors"
 	"strings"
+	"sync"
 
 	"github.com/go-kratos/kratos/v2/middleware"
-	log "github.com/nextmicro/logger"
 	configv1 "github.com/nextmicro/next/api/config/v1"
 )
 
@@ -17,10 +17,22 @@ var ErrNotFound = errors.New("Middleware has not been registered")
 // Factory is a middleware factory.
 type Factory func(*configv1.Middleware) (middleware.Middleware, error)
 
+// CloseFunc releases the resources of a middleware instance, e.g. stops its watchers.
+type CloseFunc func() error
+
+// CloserFactory is the factory of a middleware holding resources, see RegisterCloser.
+type CloserFactory func(*configv1.Middleware) (middleware.Middleware, CloseFunc, error)
+
+var (
+	closersMu sync.RWMutex
+	closers   = make(map[string]CloserFactory)
+)
+
 // Registry is the interface for callers to get registered middleware.
 type Registry interface {
 	Register(name string, factory Factory)
 	Create(cfg *configv1.Middleware) (middleware.Middleware, error)
+	Has(name string) bool
 }
 
 type middlewareRegistry struct {
@@ -47,6 +59,12 @@ func (p *middlewareRegistry) Create(cfg *configv1.Middleware) (middleware.Middle
 	return nil, ErrNotFound
 }
 
+// Has reports whether a middleware is registered with name.
+func (p *middlewareRegistry) Has(name string) bool {
+	_, ok := p.getMiddleware(createFullName(name))
+	return ok
+}
+
 func (p *middlewareRegistry) getMiddleware(name string) (Factory, bool) {
 	nameLower := strings.ToLower(name)
 	middlewareFn, ok := p.middleware[nameLower]
@@ -65,31 +83,46 @@ func Register(name string, factory Factory) {
 	globalRegistry.Register(name, factory)
 }
 
+// RegisterCloser registers one middleware holding resources, the servers close the middleware
+// built from the config once it is replaced by a reload. Create drops the close.
+func RegisterCloser(name string, factory CloserFactory) {
+	closersMu.Lock()
+	closers[createFullName(name)] = factory
+	closersMu.Unlock()
+	Register(name, func(c *configv1.Middleware) (middleware.Middleware, error) {
+		m, _, err := factory(c)
+		return m, err
+	})
+}
+
 // Create instantiates a middleware based on `cfg`.
 func Create(cfg *configv1.Middleware) (middleware.Middleware, error) {
 	return globalRegistry.Create(cfg)
 }
 
-func BuildMiddleware(kind string, _ms []*configv1.Middleware) (ms []middleware.Middleware, err error) {
-	for i := len(_ms) - 1; i >= 0; i-- {
-		_ms[i].Name = kind + "." + _ms[i].Name
-		_oldName := _ms[i].Name
-		_ms[i].Name = strings.Replace(_ms[i].Name, "grpc.", "", 1)
-		_ms[i].Name = strings.Replace(_ms[i].Name, "http.", "", 1)
-		m, err := Create(_ms[i])
-		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				log.Warnf("Skip does not exist middleware: %s", _oldName)
-				continue
-			}
-
-			log.Errorf("register middleware: [%s] error: %v", _oldName, err)
-			continue
-		}
-
-		log.Infof("register middleware: [%s] success", _oldName)
-
-		ms = append(ms, m)
+// createCloser instantiates a middleware based on `cfg` with its close, nil if it holds no resources.
+func createCloser(cfg *configv1.Middleware) (middleware.Middleware, CloseFunc, error) {
+	closersMu.RLock()
+	factory, ok := closers[createFullName(cfg.Name)]
+	closersMu.RUnlock()
+	if ok {
+		return factory(cfg)
+	}
+	m, err := Create(cfg)
+	return m, nil, err
+}
+
+// Has reports whether a middleware is registered with name.
+func Has(name string) bool {
+	return globalRegistry.Has(name)
+}
+
+// BuildMiddleware builds the middlewares configured in `_ms`, see Build.
+// The middlewares with selectors only run for the selected operations.
+func BuildMiddleware(kind string, _ms []*configv1.Middleware, opts ...BuildOption) ([]middleware.Middleware, error) {
+	entries, err := Build(kind, _ms, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return ms, nil
+	return Middlewares(entries), nil
 }
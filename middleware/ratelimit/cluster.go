@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/registry"
+	log "github.com/nextmicro/logger"
+	"github.com/nextmicro/next/config"
+	nextregistry "github.com/nextmicro/next/registry"
+)
+
+// cluster tracks the live instances of the service in the registry,
+// the watch starts on the first request so that the registry is initialized.
+type cluster struct {
+	discovery registry.Discovery
+	service   string
+	onChange  func()
+
+	once sync.Once
+	size atomic.Int64
+
+	mu      sync.Mutex
+	stopped bool
+	watcher registry.Watcher
+}
+
+func newCluster(discovery registry.Discovery, service string, onChange func()) *cluster {
+	c := &cluster{
+		discovery: discovery,
+		service:   service,
+		onChange:  onChange,
+	}
+	c.size.Store(1)
+	return c
+}
+
+// instances returns the live instances, at least 1.
+func (c *cluster) instances() int64 {
+	c.once.Do(c.start)
+	return c.size.Load()
+}
+
+func (c *cluster) start() {
+	discovery, service := c.discovery, c.service
+	if discovery == nil {
+		discovery = nextregistry.DefaultRegistry
+	}
+	if service == "" {
+		service = config.ApplicationConfig().GetName()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return
+	}
+	w, err := discovery.Watch(context.Background(), service)
+	if err != nil {
+		log.Errorf("ratelimit: watch service %s error: %v", service, err)
+		return
+	}
+	c.watcher = w
+	go c.watch(w, service)
+}
+
+// stop stops watching the service, e.g. the middleware is replaced by a reload.
+func (c *cluster) stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	if c.watcher == nil {
+		return nil
+	}
+	return c.watcher.Stop()
+}
+
+func (c *cluster) watch(w registry.Watcher, service string) {
+	for {
+		services, err := w.Next()
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, nextregistry.ErrWatcherStopped) || c.isStopped() {
+				return
+			}
+			log.Errorf("ratelimit: watch service %s error: %v", service, err)
+			time.Sleep(time.Second)
+			continue
+		}
+
+		// an instance may be returned for each endpoint
+		ids := make(map[string]struct{}, len(services))
+		for _, s := range services {
+			ids[s.ID] = struct{}{}
+		}
+		n := int64(len(ids))
+		if n == 0 {
+			n = 1
+		}
+		if prev := c.size.Swap(n); prev != n {
+			log.Infof("ratelimit: service %s has %d live instances, the cluster quotas are split by %d", service, len(ids), n)
+			c.onChange()
+		}
+	}
+}
+
+func (c *cluster) isStopped() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopped
+}
@@ -0,0 +1,136 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Algorithm is the rate limit algorithm.
+type Algorithm int32
+
+const (
+	// TokenBucket refills `quota` tokens per window, up to the burst.
+	TokenBucket Algorithm = iota
+	// SlidingWindow counts the requests in the sliding window, weighting the previous window.
+	SlidingWindow
+)
+
+// limiter decides whether a request is allowed.
+type limiter interface {
+	// reserve takes a request if it is allowed, the cancel gives it back
+	// when the request is denied by another rule.
+	reserve() (cancel func(), ok bool)
+	// setQuota updates the requests allowed per window and the burst.
+	setQuota(quota, burst float64)
+}
+
+func newLimiter(algorithm Algorithm, quota float64, window time.Duration, burst float64) limiter {
+	if algorithm == SlidingWindow {
+		return newSlidingWindow(quota, window)
+	}
+	l := &tokenBucket{window: window}
+	limit, b := l.limit(quota, burst)
+	l.Limiter = rate.NewLimiter(limit, b)
+	return l
+}
+
+type tokenBucket struct {
+	*rate.Limiter
+	window time.Duration
+}
+
+func (l *tokenBucket) limit(quota, burst float64) (rate.Limit, int) {
+	if burst <= 0 {
+		burst = quota
+	}
+	return rate.Limit(quota / l.window.Seconds()), int(math.Max(1, math.Ceil(burst)))
+}
+
+func (l *tokenBucket) reserve() (func(), bool) {
+	now := time.Now()
+	r := l.ReserveN(now, 1)
+	if !r.OK() {
+		return nil, false
+	}
+	if r.DelayFrom(now) > 0 {
+		r.CancelAt(now)
+		return nil, false
+	}
+	// the reservation takes effect at now, it is not restored if canceled after
+	return func() { r.CancelAt(now) }, true
+}
+
+func (l *tokenBucket) setQuota(quota, burst float64) {
+	limit, b := l.limit(quota, burst)
+	l.SetLimit(limit)
+	l.SetBurst(b)
+}
+
+// slidingWindow approximates the requests in the last window by the count of the current window
+// and the count of the previous window weighted by its overlap.
+type slidingWindow struct {
+	mu     sync.Mutex
+	limit  float64
+	window time.Duration
+	start  time.Time
+	prev   int64
+	curr   int64
+	now    func() time.Time
+}
+
+func newSlidingWindow(limit float64, window time.Duration) *slidingWindow {
+	return &slidingWindow{
+		limit:  limit,
+		window: window,
+		start:  time.Now(),
+		now:    time.Now,
+	}
+}
+
+func (w *slidingWindow) Allow() bool {
+	_, ok := w.reserve()
+	return ok
+}
+
+func (w *slidingWindow) reserve() (func(), bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := w.now()
+	elapsed := now.Sub(w.start)
+	if elapsed >= w.window {
+		n := elapsed / w.window
+		if n == 1 {
+			w.prev = w.curr
+		} else {
+			w.prev = 0
+		}
+		w.curr = 0
+		w.start = w.start.Add(n * w.window)
+		elapsed = now.Sub(w.start)
+	}
+
+	weight := 1 - float64(elapsed)/float64(w.window)
+	if float64(w.prev)*weight+float64(w.curr) >= w.limit {
+		return nil, false
+	}
+	w.curr++
+	start := w.start
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		// the request is counted by the previous window once the window slides
+		if w.start.Equal(start) && w.curr > 0 {
+			w.curr--
+		}
+	}, true
+}
+
+func (w *slidingWindow) setQuota(quota, _ float64) {
+	w.mu.Lock()
+	w.limit = quota
+	w.mu.Unlock()
+}
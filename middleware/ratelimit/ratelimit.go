@@ -0,0 +1,319 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	prom "github.com/go-kratos/kratos/contrib/metrics/prometheus/v2"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+	lru "github.com/hashicorp/golang-lru"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/ratelimit/v1"
+	chain "github.com/nextmicro/next/middleware"
+	identity "github.com/nextmicro/next/pkg/caller"
+	metric "github.com/nextmicro/next/pkg/metrics"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	chain.RegisterCloser("server.ratelimit", injection)
+	chain.RegisterStreamSafe("server.ratelimit")
+}
+
+// ErrLimitExceed is service unavailable due to rate limit exceeded.
+var ErrLimitExceed = errors.New(429, "RATELIMIT", "service unavailable due to rate limit exceeded")
+
+func injection(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	cfg := &v1.RateLimit{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rules := make([]Rule, 0, len(cfg.GetRules()))
+	for _, r := range cfg.GetRules() {
+		rules = append(rules, Rule{
+			Operation: r.GetOperation(),
+			Caller:    r.GetCaller(),
+			Metadata:  r.GetMetadata(),
+			Algorithm: Algorithm(r.GetAlgorithm()),
+			Quota:     r.GetQuota(),
+			Window:    r.GetWindow().AsDuration(),
+			Burst:     r.GetBurst(),
+			KeyBy:     r.GetKeyBy(),
+			Cluster:   r.GetCluster(),
+		})
+	}
+	for i, r := range rules {
+		if err := r.validate(); err != nil {
+			return nil, nil, fmt.Errorf("ratelimit: rules[%d]: %v", i, err)
+		}
+	}
+
+	m, closeFn := server(WithRules(rules...))
+	return m, closeFn, nil
+}
+
+// Rule limits the matched requests to `Quota` requests per `Window`.
+type Rule struct {
+	// Operation is the glob pattern of the operation, empty matches all.
+	Operation string
+	// Caller is the glob pattern of the caller, empty matches all.
+	Caller string
+	// Metadata are the glob patterns of the metadata or request header values.
+	Metadata  map[string]string
+	Algorithm Algorithm
+	Quota     int64
+	// Window defaults to 1s.
+	Window time.Duration
+	// Burst is the token bucket size, defaults to the quota.
+	Burst int64
+	// KeyBy splits the quota for each distinct value of the keys: operation, caller or a metadata key.
+	KeyBy []string
+	// MaxKeys is the limiters kept for the distinct keys, the least recently used are evicted, defaults to 10000.
+	MaxKeys int
+	// Cluster the quota is for the whole service, each instance enforces quota / live instances.
+	Cluster bool
+}
+
+func (r Rule) validate() error {
+	if r.Quota <= 0 {
+		return fmt.Errorf("quota must be positive, got %d", r.Quota)
+	}
+	if r.Window < 0 {
+		return fmt.Errorf("window must not be negative, got %s", r.Window)
+	}
+	patterns := []string{r.Operation, r.Caller}
+	for _, pattern := range r.Metadata {
+		patterns = append(patterns, pattern)
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// request is the attributes of the request matched by the rules.
+type request struct {
+	kind      string
+	operation string
+	caller    string
+	md        metadata.Metadata
+	header    transport.Header
+}
+
+func (r *request) value(key string) string {
+	switch key {
+	case "operation":
+		return r.operation
+	case "caller":
+		return r.caller
+	}
+	if v := r.md.Get(key); v != "" {
+		return v
+	}
+	if r.header != nil {
+		return r.header.Get(key)
+	}
+	return ""
+}
+
+const defaultMaxKeys = 10000
+
+// rule is the rule with its limiters, one for each distinct key.
+type rule struct {
+	Rule
+	cluster *cluster
+	// limiters is bounded, an evicted key starts with a full quota.
+	limiters *lru.Cache
+}
+
+func newRule(r Rule, c *cluster) *rule {
+	if r.Window <= 0 {
+		r.Window = time.Second
+	}
+	if r.MaxKeys <= 0 {
+		r.MaxKeys = defaultMaxKeys
+	}
+	limiters, _ := lru.New(r.MaxKeys)
+	rl := &rule{Rule: r, limiters: limiters}
+	if r.Cluster {
+		rl.cluster = c
+	}
+	return rl
+}
+
+// quota returns the quota and burst of the instance.
+func (r *rule) quota() (float64, float64) {
+	n := int64(1)
+	if r.cluster != nil {
+		n = r.cluster.instances()
+	}
+	return float64(r.Quota) / float64(n), float64(r.Burst) / float64(n)
+}
+
+// resize updates the limiters with the quota of the instance.
+func (r *rule) resize() {
+	quota, burst := r.quota()
+	for _, key := range r.limiters.Keys() {
+		if l, ok := r.limiters.Peek(key); ok {
+			l.(limiter).setQuota(quota, burst)
+		}
+	}
+}
+
+func (r *rule) match(req *request) bool {
+	if !matchPattern(r.Operation, req.operation) || !matchPattern(r.Caller, req.caller) {
+		return false
+	}
+	for key, pattern := range r.Metadata {
+		if !matchPattern(pattern, req.value(key)) {
+			return false
+		}
+	}
+	return true
+}
+
+// reserve takes a request from the limiter of the request key.
+func (r *rule) reserve(req *request) (func(), bool) {
+	var key string
+	if len(r.KeyBy) > 0 {
+		values := make([]string, 0, len(r.KeyBy))
+		for _, k := range r.KeyBy {
+			values = append(values, req.value(k))
+		}
+		key = strings.Join(values, "\x00")
+	}
+	if l, ok := r.limiters.Get(key); ok {
+		return l.(limiter).reserve()
+	}
+	quota, burst := r.quota()
+	var l interface{} = newLimiter(r.Algorithm, quota, r.Window, burst)
+	if prev, ok, _ := r.limiters.PeekOrAdd(key, l); ok {
+		l = prev
+	}
+	return l.(limiter).reserve()
+}
+
+func matchPattern(pattern, s string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	matched, _ := path.Match(pattern, s)
+	return matched
+}
+
+// Option is ratelimit option.
+type Option func(*Options)
+
+// Options is ratelimit options.
+type Options struct {
+	rules     []Rule
+	discovery registry.Discovery
+	service   string
+
+	// counter: requests_ratelimit_total{kind, caller, method}
+	denied metrics.Counter
+}
+
+// WithRules with the rate limit rules.
+func WithRules(rules ...Rule) Option {
+	return func(o *Options) {
+		o.rules = rules
+	}
+}
+
+// WithCluster with the registry and the service watched for the cluster quotas,
+// default: registry.DefaultRegistry and the application name.
+func WithCluster(discovery registry.Discovery, service string) Option {
+	return func(o *Options) {
+		o.discovery = discovery
+		o.service = service
+	}
+}
+
+// WithDenied with the denied requests counter.
+func WithDenied(c metrics.Counter) Option {
+	return func(o *Options) {
+		o.denied = c
+	}
+}
+
+// Server limits the requests by the rules, the request must be allowed by all the matched rules,
+// a denied request takes no quota of the other matched rules.
+func Server(opts ...Option) middleware.Middleware {
+	m, _ := server(opts...)
+	return m
+}
+
+// server returns the middleware with the close stopping the cluster watch.
+func server(opts ...Option) (middleware.Middleware, chain.CloseFunc) {
+	options := Options{
+		denied: prom.NewCounter(metric.MetricRateLimitTotal),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+	rules := make([]*rule, 0, len(options.rules))
+	c := newCluster(options.discovery, options.service, func() {
+		for _, r := range rules {
+			if r.cluster != nil {
+				r.resize()
+			}
+		}
+	})
+	for _, r := range options.rules {
+		rules = append(rules, newRule(r, c))
+	}
+
+	m := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			if len(rules) == 0 {
+				return handler(ctx, req)
+			}
+
+			r := &request{}
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				r.kind = tr.Kind().String()
+				r.operation = tr.Operation()
+				r.header = tr.RequestHeader()
+			}
+			if c, ok := identity.FromContext(ctx); ok {
+				r.caller = c.Name
+			}
+			r.md, _ = metadata.FromServerContext(ctx)
+
+			cancels := make([]func(), 0, len(rules))
+			for _, rl := range rules {
+				if !rl.match(r) {
+					continue
+				}
+				cancel, ok := rl.reserve(r)
+				if !ok {
+					for _, cancel := range cancels {
+						cancel()
+					}
+					if options.denied != nil {
+						options.denied.With(r.kind, r.caller, r.operation).Inc()
+					}
+					return nil, ErrLimitExceed
+				}
+				cancels = append(cancels, cancel)
+			}
+			return handler(ctx, req)
+		}
+	}
+	return m, c.stop
+}
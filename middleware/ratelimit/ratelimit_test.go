@@ -0,0 +1,208 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/transport"
+	identity "github.com/nextmicro/next/pkg/caller"
+	nextregistry "github.com/nextmicro/next/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+type Transport struct {
+	transport.Transporter
+	operation string
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindGRPC
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+func (tr *Transport) RequestHeader() transport.Header {
+	return nil
+}
+
+type denied struct {
+	metrics.Counter
+	labels [][]string
+}
+
+func (c *denied) With(lvs ...string) metrics.Counter {
+	c.labels = append(c.labels, lvs)
+	return c
+}
+
+func (c *denied) Inc() {}
+
+func newContext(operation, caller string, md metadata.Metadata) context.Context {
+	ctx := transport.NewServerContext(context.Background(), &Transport{operation: operation})
+	ctx = identity.NewContext(ctx, identity.Caller{Name: caller})
+	return metadata.NewServerContext(ctx, md)
+}
+
+func handler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func TestServer(t *testing.T) {
+	counter := &denied{}
+	h := Server(
+		WithRules(
+			Rule{Operation: "/helloworld.Greeter/*", Caller: "order", Quota: 2, Window: time.Hour},
+			Rule{Metadata: map[string]string{"x-md-global-tenant": "free-*"}, Quota: 1, Window: time.Hour},
+		),
+		WithDenied(counter),
+	)(handler)
+
+	allowed := func(ctx context.Context) bool {
+		_, err := h(ctx, nil)
+		if err != nil {
+			assert.Equal(t, ErrLimitExceed, err)
+		}
+		return err == nil
+	}
+
+	// caller order may call the greeter twice
+	assert.True(t, allowed(newContext("/helloworld.Greeter/SayHello", "order", nil)))
+	assert.True(t, allowed(newContext("/helloworld.Greeter/SayHello", "order", nil)))
+	assert.False(t, allowed(newContext("/helloworld.Greeter/SayHello", "order", nil)))
+	assert.True(t, allowed(newContext("/helloworld.Greeter/SayHello", "user", nil)))
+	assert.True(t, allowed(newContext("/helloworld.User/Get", "order", nil)))
+
+	// the free tenants share the quota
+	md := metadata.Metadata{"x-md-global-tenant": []string{"free-1"}}
+	assert.True(t, allowed(newContext("/helloworld.User/Get", "user", md)))
+	assert.False(t, allowed(newContext("/helloworld.User/Get", "user", md)))
+
+	assert.Equal(t, [][]string{
+		{"grpc", "order", "/helloworld.Greeter/SayHello"},
+		{"grpc", "user", "/helloworld.User/Get"},
+	}, counter.labels)
+}
+
+func TestServerKeyBy(t *testing.T) {
+	h := Server(WithRules(Rule{Quota: 1, Window: time.Hour, KeyBy: []string{"caller"}}), WithDenied(nil))(handler)
+
+	for _, caller := range []string{"order", "user"} {
+		_, err := h(newContext("/a", caller, nil), nil)
+		assert.NoError(t, err)
+		_, err = h(newContext("/a", caller, nil), nil)
+		assert.Equal(t, ErrLimitExceed, err)
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	now := time.Unix(0, 0)
+	w := newSlidingWindow(4, time.Second)
+	w.start, w.now = now, func() time.Time { return now }
+
+	for i := 0; i < 4; i++ {
+		assert.True(t, w.Allow())
+	}
+	assert.False(t, w.Allow())
+
+	// half of the previous window counts
+	now = now.Add(1500 * time.Millisecond)
+	assert.True(t, w.Allow())
+	assert.True(t, w.Allow())
+	assert.False(t, w.Allow())
+
+	// the previous windows are expired
+	now = now.Add(3 * time.Second)
+	for i := 0; i < 4; i++ {
+		assert.True(t, w.Allow())
+	}
+	assert.False(t, w.Allow())
+}
+
+func TestValidate(t *testing.T) {
+	assert.Error(t, Rule{}.validate())
+	assert.Error(t, Rule{Quota: 1, Operation: "["}.validate())
+	assert.NoError(t, Rule{Quota: 1, Operation: "/helloworld.Greeter/*"}.validate())
+}
+
+func TestClusterQuota(t *testing.T) {
+	r := nextregistry.NewMemory()
+	a := &registry.ServiceInstance{ID: "a", Name: "greeter"}
+	b := &registry.ServiceInstance{ID: "b", Name: "greeter"}
+	for _, si := range []*registry.ServiceInstance{a, b} {
+		assert.NoError(t, r.Register(context.Background(), si))
+	}
+
+	var rules []*rule
+	c := newCluster(r, "greeter", func() {
+		for _, rl := range rules {
+			rl.resize()
+		}
+	})
+	rl := newRule(Rule{Algorithm: SlidingWindow, Quota: 4, Window: time.Hour, Cluster: true}, c)
+	rules = append(rules, rl)
+	assert.Eventually(t, func() bool { return c.instances() == 2 }, time.Second, time.Millisecond)
+
+	// each of the two instances allows half of the quota
+	req := &request{}
+	assert.True(t, allow(rl, req))
+	assert.True(t, allow(rl, req))
+	assert.False(t, allow(rl, req))
+
+	// the instance left allows the whole quota
+	assert.NoError(t, r.Deregister(context.Background(), a))
+	assert.Eventually(t, func() bool { return c.instances() == 1 }, time.Second, time.Millisecond)
+	assert.True(t, allow(rl, req))
+	assert.True(t, allow(rl, req))
+	assert.False(t, allow(rl, req))
+
+	// the stopped cluster is not resized anymore
+	assert.NoError(t, c.stop())
+	assert.NoError(t, r.Register(context.Background(), a))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int64(1), c.instances())
+}
+
+func TestServerDeniedTakesNoQuota(t *testing.T) {
+	h := Server(WithRules(
+		Rule{Operation: "/a", Quota: 2, Window: time.Hour},
+		Rule{Operation: "/a", Caller: "user", Quota: 1, Window: time.Hour, Algorithm: SlidingWindow},
+	), WithDenied(nil))(handler)
+
+	_, err := h(newContext("/a", "user", nil), nil)
+	assert.NoError(t, err)
+	// denied by the second rule, the first rule keeps its quota
+	for i := 0; i < 3; i++ {
+		_, err = h(newContext("/a", "user", nil), nil)
+		assert.Equal(t, ErrLimitExceed, err)
+	}
+	_, err = h(newContext("/a", "order", nil), nil)
+	assert.NoError(t, err)
+	_, err = h(newContext("/a", "order", nil), nil)
+	assert.Equal(t, ErrLimitExceed, err)
+}
+
+func TestRuleMaxKeys(t *testing.T) {
+	r := newRule(Rule{Quota: 1, Window: time.Hour, KeyBy: []string{"caller"}, MaxKeys: 2}, nil)
+	for _, caller := range []string{"a", "b", "c", "d"} {
+		_, ok := r.reserve(&request{caller: caller})
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 2, r.limiters.Len())
+
+	// the evicted key starts with a full quota
+	_, ok := r.reserve(&request{caller: "a"})
+	assert.True(t, ok)
+	_, ok = r.reserve(&request{caller: "a"})
+	assert.False(t, ok)
+}
+
+func allow(rl *rule, req *request) bool {
+	_, ok := rl.reserve(req)
+	return ok
+}
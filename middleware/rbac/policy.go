@@ -0,0 +1,127 @@
+package rbac
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// subject is the identity of the request.
+type subject struct {
+	caller string
+	roles  []string
+	scopes []string
+}
+
+// policy is the rules grouped by the selector, matched like Server.Use:
+// the exact operation first, then the longest prefix.
+type policy struct {
+	defaultAllow bool
+	exact        map[string][]Rule
+	prefix       []string
+	prefixRules  map[string][]Rule
+}
+
+func newPolicy(rules []Rule, defaultAllow bool) (*policy, error) {
+	p := &policy{
+		defaultAllow: defaultAllow,
+		exact:        make(map[string][]Rule),
+		prefixRules:  make(map[string][]Rule),
+	}
+	for i, r := range rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("rbac: rules[%d]: %v", i, err)
+		}
+		if strings.HasSuffix(r.Selector, "*") {
+			prefix := strings.TrimSuffix(r.Selector, "*")
+			if _, ok := p.prefixRules[prefix]; !ok {
+				p.prefix = append(p.prefix, prefix)
+			}
+			p.prefixRules[prefix] = append(p.prefixRules[prefix], r)
+			continue
+		}
+		p.exact[r.Selector] = append(p.exact[r.Selector], r)
+	}
+	// sort the prefix:
+	//  - /foo/bar
+	//  - /foo
+	sort.Slice(p.prefix, func(i, j int) bool {
+		return p.prefix[i] > p.prefix[j]
+	})
+	return p, nil
+}
+
+func (p *policy) match(operation string) ([]Rule, bool) {
+	if rules, ok := p.exact[operation]; ok {
+		return rules, true
+	}
+	for _, prefix := range p.prefix {
+		if strings.HasPrefix(operation, prefix) {
+			return p.prefixRules[prefix], true
+		}
+	}
+	return nil, false
+}
+
+func (p *policy) allow(operation string, s *subject) bool {
+	rules, ok := p.match(operation)
+	if !ok {
+		return p.defaultAllow
+	}
+	for _, r := range rules {
+		if r.grant(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether values contains one of the required, true if required is empty.
+func containsAny(values, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+	for _, r := range required {
+		for _, v := range values {
+			if v == r {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchAny reports whether s matches one of the patterns, true if patterns is empty.
+func matchAny(patterns []string, s string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, s); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// claimValues returns the values of the claim, a space separated string or a list.
+func claimValues(claims jwt.MapClaims, key string) []string {
+	switch v := claims[key].(type) {
+	case string:
+		return strings.Fields(v)
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync/atomic"
+
+	prom "github.com/go-kratos/kratos/contrib/metrics/prometheus/v2"
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	log "github.com/nextmicro/logger"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/rbac/v1"
+	conf "github.com/nextmicro/next/config"
+	chain "github.com/nextmicro/next/middleware"
+	jwtauth "github.com/nextmicro/next/middleware/auth/jwt"
+	identity "github.com/nextmicro/next/pkg/caller"
+	metric "github.com/nextmicro/next/pkg/metrics"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	chain.RegisterCloser("server.rbac", injection)
+	chain.RegisterStreamSafe("server.rbac")
+}
+
+const reason = "FORBIDDEN"
+
+// ErrForbidden is the request denied by the rules.
+var ErrForbidden = kerrors.Forbidden(reason, "permission denied")
+
+func injection(c *config.Middleware) (middleware.Middleware, chain.CloseFunc, error) {
+	cfg := &v1.RBAC{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, cfg, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rules := rulesFromConfig(cfg)
+	if _, err := newPolicy(rules, cfg.GetDefaultAllow()); err != nil {
+		return nil, nil, err
+	}
+
+	opts := []Option{
+		WithRules(rules...),
+		WithDefaultAllow(cfg.GetDefaultAllow()),
+		WithWatch(cfg.GetWatchKey()),
+	}
+	if cfg.GetRolesClaim() != "" {
+		opts = append(opts, WithRolesClaim(cfg.GetRolesClaim()))
+	}
+	if cfg.GetScopesClaim() != "" {
+		opts = append(opts, WithScopesClaim(cfg.GetScopesClaim()))
+	}
+	m, closeFn := server(opts...)
+	return m, closeFn, nil
+}
+
+func rulesFromConfig(cfg *v1.RBAC) []Rule {
+	rules := make([]Rule, 0, len(cfg.GetRules()))
+	for _, r := range cfg.GetRules() {
+		rules = append(rules, Rule{
+			Selector: r.GetSelector(),
+			Roles:    r.GetRoles(),
+			Scopes:   r.GetScopes(),
+			Callers:  r.GetCallers(),
+		})
+	}
+	return rules
+}
+
+// Rule grants the selected operations to the requests matching all the non-empty conditions.
+type Rule struct {
+	// Selector is the operation selector, the same as Server.Use:
+	//   - '/*'
+	//   - '/helloworld.v1.Greeter/*'
+	//   - '/helloworld.v1.Greeter/SayHello'
+	Selector string
+	// Roles one of the roles is required.
+	Roles []string
+	// Scopes one of the scopes is required.
+	Scopes []string
+	// Callers are the glob patterns of the caller service, one of them is required.
+	// The caller is the x-md-local-caller metadata set by the client, it is not authenticated,
+	// so the callers only narrow the grant of the roles or scopes from a verified token.
+	Callers []string
+}
+
+func (r Rule) validate() error {
+	if r.Selector == "" {
+		return errors.New("selector is required")
+	}
+	for _, pattern := range r.Callers {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid caller pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+func (r Rule) grant(s *subject) bool {
+	return containsAny(s.roles, r.Roles) && containsAny(s.scopes, r.Scopes) && matchAny(r.Callers, s.caller)
+}
+
+// Option is rbac option.
+type Option func(*Options)
+
+// Options is rbac options.
+type Options struct {
+	rules        []Rule
+	defaultAllow bool
+	watchKey     string
+	rolesClaim   string
+	scopesClaim  string
+
+	// counter: requests_rbac_denied_total{kind, caller, method}
+	denied metrics.Counter
+}
+
+// WithRules with the rules.
+func WithRules(rules ...Rule) Option {
+	return func(o *Options) {
+		o.rules = rules
+	}
+}
+
+// WithDefaultAllow allow the operations not selected by any rule, default: denied.
+func WithDefaultAllow(allow bool) Option {
+	return func(o *Options) {
+		o.defaultAllow = allow
+	}
+}
+
+// WithWatch with the config key watched to hot reload the rules.
+func WithWatch(key string) Option {
+	return func(o *Options) {
+		o.watchKey = key
+	}
+}
+
+// WithRolesClaim with the claim of the roles, default: roles.
+func WithRolesClaim(claim string) Option {
+	return func(o *Options) {
+		o.rolesClaim = claim
+	}
+}
+
+// WithScopesClaim with the claim of the scopes, default: scope.
+func WithScopesClaim(claim string) Option {
+	return func(o *Options) {
+		o.scopesClaim = claim
+	}
+}
+
+// WithDenied with the denied requests counter.
+func WithDenied(c metrics.Counter) Option {
+	return func(o *Options) {
+		o.denied = c
+	}
+}
+
+// Server authorizes the requests by the rules of the most specific selector,
+// the roles and scopes are from the claims of the auth middleware.
+func Server(opts ...Option) middleware.Middleware {
+	m, _ := server(opts...)
+	return m
+}
+
+// server returns the middleware with the close stopping the watch of the rules.
+func server(opts ...Option) (middleware.Middleware, chain.CloseFunc) {
+	options := Options{
+		rolesClaim:  "roles",
+		scopesClaim: "scope",
+		denied:      prom.NewCounter(metric.MetricRBACDeniedTotal),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	var current atomic.Pointer[policy]
+	p, err := newPolicy(options.rules, options.defaultAllow)
+	if err != nil {
+		log.Errorf("rbac: invalid rules, deny all: %v", err)
+		p = &policy{}
+	}
+	current.Store(p)
+	var unsubscribe func()
+	if options.watchKey != "" {
+		unsubscribe = watch(options.watchKey, &current)
+	}
+	closeFn := func() error {
+		if unsubscribe != nil {
+			unsubscribe()
+		}
+		return nil
+	}
+
+	m := func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			var kind, operation string
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				kind = tr.Kind().String()
+				operation = tr.Operation()
+			}
+			s := &subject{}
+			if c, ok := identity.FromContext(ctx); ok {
+				s.caller = c.Name
+			}
+			if claims, ok := jwtauth.FromContext(ctx); ok {
+				s.roles = claimValues(claims, options.rolesClaim)
+				s.scopes = claimValues(claims, options.scopesClaim)
+			}
+
+			if !current.Load().allow(operation, s) {
+				if options.denied != nil {
+					options.denied.With(kind, s.caller, operation).Inc()
+				}
+				return nil, ErrForbidden.WithMetadata(map[string]string{
+					"operation": operation,
+					"caller":    s.caller,
+				})
+			}
+			return handler(ctx, req)
+		}
+	}
+	return m, closeFn
+}
+
+// watch reloads the rules when the config of key changes, the invalid rules are ignored.
+// It returns the unsubscribe of the watch.
+func watch(key string, current *atomic.Pointer[policy]) func() {
+	if conf.DefaultConfig == nil {
+		log.Warnf("rbac: config is not loaded, skip watching [%s]", key)
+		return nil
+	}
+
+	unsubscribe, err := conf.Subscribe(key, func(_ string, value kconfig.Value) {
+		cfg := &v1.RBAC{}
+		if err := value.Scan(cfg); err != nil {
+			log.Errorf("rbac: watch [%s] scan error: %v", key, err)
+			return
+		}
+		p, err := newPolicy(rulesFromConfig(cfg), cfg.GetDefaultAllow())
+		if err != nil {
+			log.Errorf("rbac: watch [%s] invalid rules, keep the old rules: %v", key, err)
+			return
+		}
+		current.Store(p)
+		log.Infof("rbac: watch [%s] reloaded rules: %d", key, len(cfg.GetRules()))
+	})
+	if err != nil && !errors.Is(err, kconfig.ErrNotFound) {
+		log.Errorf("rbac: watch [%s] error: %v", key, err)
+	}
+	return unsubscribe
+}
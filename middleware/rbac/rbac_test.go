@@ -0,0 +1,167 @@
+package rbac
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/metrics"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	conf "github.com/nextmicro/next/config"
+	jwtauth "github.com/nextmicro/next/middleware/auth/jwt"
+	identity "github.com/nextmicro/next/pkg/caller"
+	"github.com/stretchr/testify/assert"
+)
+
+type Transport struct {
+	transport.Transporter
+	operation string
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindGRPC
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+type denied struct {
+	metrics.Counter
+	labels [][]string
+}
+
+func (c *denied) With(lvs ...string) metrics.Counter {
+	c.labels = append(c.labels, lvs)
+	return c
+}
+
+func (c *denied) Inc() {}
+
+func newContext(operation, caller string, claims jwt.MapClaims) context.Context {
+	ctx := transport.NewServerContext(context.Background(), &Transport{operation: operation})
+	if caller != "" {
+		ctx = identity.NewContext(ctx, identity.Caller{Name: caller})
+	}
+	if claims != nil {
+		ctx = jwtauth.NewContext(ctx, "token", claims)
+	}
+	return ctx
+}
+
+func handler(context.Context, interface{}) (interface{}, error) {
+	return "ok", nil
+}
+
+func TestServer(t *testing.T) {
+	counter := &denied{}
+	h := Server(
+		WithRules(
+			Rule{Selector: "/admin.v1.Admin/*", Roles: []string{"admin"}},
+			Rule{Selector: "/admin.v1.Admin/*", Callers: []string{"ops-*"}},
+			Rule{Selector: "/admin.v1.Admin/ListUsers", Scopes: []string{"users:read"}},
+			Rule{Selector: "/*", Roles: []string{"user", "admin"}},
+		),
+		WithDenied(counter),
+	)(handler)
+
+	tests := []struct {
+		name      string
+		operation string
+		caller    string
+		claims    jwt.MapClaims
+		allowed   bool
+	}{
+		{"role", "/admin.v1.Admin/DeleteUser", "", jwt.MapClaims{"roles": []interface{}{"admin"}}, true},
+		{"caller", "/admin.v1.Admin/DeleteUser", "ops-cron", nil, true},
+		{"no role", "/admin.v1.Admin/DeleteUser", "bff", jwt.MapClaims{"roles": []interface{}{"user"}}, false},
+		{"exact selector", "/admin.v1.Admin/ListUsers", "", jwt.MapClaims{"scope": "users:read users:write"}, true},
+		{"exact selector overrides prefix", "/admin.v1.Admin/ListUsers", "", jwt.MapClaims{"roles": "admin"}, false},
+		{"default selector", "/helloworld.v1.Greeter/SayHello", "", jwt.MapClaims{"roles": "user"}, true},
+		{"anonymous", "/helloworld.v1.Greeter/SayHello", "", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := h(newContext(tt.operation, tt.caller, tt.claims), nil)
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.IsForbidden(err))
+			assert.Equal(t, reason, errors.Reason(err))
+			assert.Equal(t, tt.operation, errors.FromError(err).GetMetadata()["operation"])
+		})
+	}
+	assert.Len(t, counter.labels, 3)
+	assert.Equal(t, []string{"grpc", "bff", "/admin.v1.Admin/DeleteUser"}, counter.labels[0])
+}
+
+func TestServerDefaultDeny(t *testing.T) {
+	h := Server(WithRules(Rule{Selector: "/helloworld.v1.Greeter/*"}))(handler)
+
+	_, err := h(newContext("/helloworld.v1.Greeter/SayHello", "", nil), nil)
+	assert.NoError(t, err)
+	_, err = h(newContext("/admin.v1.Admin/DeleteUser", "", nil), nil)
+	assert.True(t, errors.IsForbidden(err))
+
+	h = Server(
+		WithRules(Rule{Selector: "/helloworld.v1.Greeter/*"}),
+		WithDefaultAllow(true),
+	)(handler)
+	_, err = h(newContext("/admin.v1.Admin/DeleteUser", "", nil), nil)
+	assert.NoError(t, err)
+}
+
+func TestServerWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write(`
+rbac:
+  rules:
+    - selector: /*
+      roles: [admin]
+`)
+	c := kconfig.New(kconfig.WithSource(file.NewSource(path)))
+	assert.NoError(t, c.Load())
+	defer c.Close()
+	old := conf.DefaultConfig
+	conf.DefaultConfig = c
+	defer func() { conf.DefaultConfig = old }()
+
+	h := Server(
+		WithRules(Rule{Selector: "/*", Roles: []string{"admin"}}),
+		WithWatch("rbac"),
+	)(handler)
+	ctx := newContext("/helloworld.v1.Greeter/SayHello", "", jwt.MapClaims{"roles": "user"})
+	_, err := h(ctx, nil)
+	assert.True(t, errors.IsForbidden(err))
+
+	write(`
+rbac:
+  rules:
+    - selector: /*
+      roles: [admin, user]
+`)
+	assert.Eventually(t, func() bool {
+		_, err = h(ctx, nil)
+		return err == nil
+	}, 3*time.Second, 10*time.Millisecond)
+
+	// the invalid rules are ignored
+	write(`
+rbac:
+  rules:
+    - roles: [admin]
+`)
+	time.Sleep(100 * time.Millisecond)
+	_, err = h(ctx, nil)
+	assert.NoError(t, err)
+}
@@ -17,6 +17,7 @@ import (
 func init() {
 	chain.Register("client.recovery", injection)
 	chain.Register("server.recovery", injection)
+	chain.RegisterStreamSafe("client.recovery", "server.recovery")
 }
 
 // ErrPanicRecover is panic recover error.
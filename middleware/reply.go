@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"context"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type newReplyKey struct{}
+
+// NewReplyContext returns a new context which makes the client transports decode the reply
+// into a new object for each call of the handler, the handler returns the new object.
+// It is used by the middlewares calling the handler concurrently, e.g. hedging.
+func NewReplyContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, newReplyKey{}, true)
+}
+
+// IsNewReply reports whether the reply should be decoded into a new object.
+func IsNewReply(ctx context.Context) bool {
+	v, _ := ctx.Value(newReplyKey{}).(bool)
+	return v
+}
+
+// NewReply returns a new object of the reply type, the reply itself if it is not a pointer.
+func NewReply(reply interface{}) interface{} {
+	if m, ok := reply.(proto.Message); ok {
+		return m.ProtoReflect().New().Interface()
+	}
+	v := reflect.ValueOf(reply)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return reply
+	}
+	return reflect.New(v.Elem().Type()).Interface()
+}
+
+// CopyReply copies the reply decoded into a new object back to `dst`.
+func CopyReply(dst, src interface{}) {
+	dv, sv := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() || !sv.IsValid() || dv.Type() != sv.Type() || dv.Pointer() == sv.Pointer() {
+		return
+	}
+	if m, ok := dst.(proto.Message); ok {
+		proto.Reset(m)
+		proto.Merge(m, src.(proto.Message))
+		return
+	}
+	dv.Elem().Set(sv.Elem())
+}
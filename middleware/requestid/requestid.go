@@ -0,0 +1,100 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	chain "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/pkg/requestid"
+)
+
+const namespace = "requestid"
+
+func init() {
+	chain.Register("client."+namespace, injectionClient)
+	chain.Register("server."+namespace, injectionServer)
+	chain.RegisterStreamSafe("client."+namespace, "server."+namespace)
+}
+
+// Option is requestid option.
+type Option func(*options)
+
+type options struct {
+	generator func() string
+}
+
+// WithGenerator with the generator of the request id, default: uuid.
+func WithGenerator(f func() string) Option {
+	return func(o *options) {
+		o.generator = f
+	}
+}
+
+func injectionServer(_ *config.Middleware) (middleware.Middleware, error) {
+	return Server(), nil
+}
+
+// Server accepts the X-Request-ID of the request or generates one,
+// puts it into the context and the server metadata, and echoes it in the response header.
+func Server(opts ...Option) middleware.Middleware {
+	o := &options{
+		generator: requestid.New,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+
+			id := tr.RequestHeader().Get(requestid.HeaderKey)
+			if id == "" {
+				id = tr.RequestHeader().Get(requestid.MetadataKey)
+			}
+			if id == "" {
+				id = o.generator()
+			}
+
+			md, ok := metadata.FromServerContext(ctx)
+			if ok {
+				md = md.Clone()
+			} else {
+				md = metadata.New()
+			}
+			md.Set(requestid.MetadataKey, id)
+			ctx = metadata.NewServerContext(requestid.NewContext(ctx, id), md)
+
+			if header := tr.ReplyHeader(); header != nil {
+				header.Set(requestid.HeaderKey, id)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+func injectionClient(_ *config.Middleware) (middleware.Middleware, error) {
+	return Client(), nil
+}
+
+// Client forwards the request id of the context in the X-Request-ID header.
+func Client() middleware.Middleware {
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok {
+				return handler(ctx, req)
+			}
+			if id, ok := requestid.FromContext(ctx); ok && tr.RequestHeader().Get(requestid.HeaderKey) == "" {
+				tr.RequestHeader().Set(requestid.HeaderKey, id)
+			}
+			return handler(ctx, req)
+		}
+	}
+}
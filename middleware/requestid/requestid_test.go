@@ -0,0 +1,84 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/nextmicro/next/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string { return http.Header(hc).Get(key) }
+
+func (hc headerCarrier) Set(key string, value string) { http.Header(hc).Set(key, value) }
+
+func (hc headerCarrier) Add(key string, value string) { http.Header(hc).Add(key, value) }
+
+func (hc headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(hc))
+	for k := range http.Header(hc) {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type Transport struct {
+	transport.Transporter
+	reqHeader   headerCarrier
+	replyHeader headerCarrier
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return transport.KindHTTP
+}
+
+func (tr *Transport) RequestHeader() transport.Header {
+	return tr.reqHeader
+}
+
+func (tr *Transport) ReplyHeader() transport.Header {
+	return tr.replyHeader
+}
+
+func handler(ctx context.Context, _ interface{}) (interface{}, error) {
+	id, _ := requestid.FromContext(ctx)
+	md, _ := metadata.FromServerContext(ctx)
+	return []string{id, md.Get(requestid.MetadataKey)}, nil
+}
+
+func TestServer(t *testing.T) {
+	h := Server(WithGenerator(func() string { return "generated" }))(handler)
+
+	tr := &Transport{reqHeader: headerCarrier{}, replyHeader: headerCarrier{}}
+	tr.reqHeader.Set(requestid.HeaderKey, "abc")
+	reply, err := h(transport.NewServerContext(context.Background(), tr), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abc", "abc"}, reply)
+	assert.Equal(t, "abc", tr.replyHeader.Get(requestid.HeaderKey))
+
+	tr = &Transport{reqHeader: headerCarrier{}, replyHeader: headerCarrier{}}
+	reply, err = h(transport.NewServerContext(context.Background(), tr), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"generated", "generated"}, reply)
+	assert.Equal(t, "generated", tr.replyHeader.Get(requestid.HeaderKey))
+}
+
+func TestClient(t *testing.T) {
+	next := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		tr, _ := transport.FromClientContext(ctx)
+		return tr.RequestHeader().Get(requestid.HeaderKey), nil
+	}
+
+	ctx := requestid.NewContext(context.Background(), "abc")
+	ctx = transport.NewClientContext(ctx, &Transport{reqHeader: headerCarrier{}})
+	reply, err := Client()(next)(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", reply)
+}
@@ -0,0 +1,328 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/retry/v1"
+	chain "github.com/nextmicro/next/middleware"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func init() {
+	// calls the handler again on failure, it is not adapted to streams
+	chain.Register("client.retry", injection)
+}
+
+func injection(c *config.Middleware) (middleware.Middleware, error) {
+	options := &v1.Retry{}
+	if c.Options != nil {
+		if err := anypb.UnmarshalTo(c.Options, options, proto.UnmarshalOptions{Merge: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	if options.GetAttempts() < 0 {
+		return nil, fmt.Errorf("retry: attempts must not be negative, got %d", options.GetAttempts())
+	}
+	if b := options.GetBackoff(); b != nil {
+		if b.GetBase().AsDuration() < 0 || b.GetMax().AsDuration() < 0 || b.GetMultiplier() < 0 || b.GetJitter() < 0 {
+			return nil, fmt.Errorf("retry: backoff must not be negative")
+		}
+	}
+	if options.GetBudget().GetRatio() < 0 || options.GetBudget().GetMaxTokens() < 0 {
+		return nil, fmt.Errorf("retry: budget must not be negative")
+	}
+
+	opts := make([]Option, 0, 7)
+	if options.GetAttempts() > 0 {
+		opts = append(opts, WithAttempts(int(options.GetAttempts())))
+	}
+	if b := options.GetBackoff(); b != nil {
+		opts = append(opts, WithBackoff(b.GetBase().AsDuration(), b.GetMax().AsDuration(), b.GetMultiplier(), b.GetJitter()))
+	}
+	if len(options.GetReasons()) > 0 {
+		opts = append(opts, WithReasons(options.GetReasons()...))
+	}
+	if len(options.GetHttpCodes()) > 0 {
+		httpCodes := make([]int, 0, len(options.GetHttpCodes()))
+		for _, code := range options.GetHttpCodes() {
+			httpCodes = append(httpCodes, int(code))
+		}
+		opts = append(opts, WithHTTPCodes(httpCodes...))
+	}
+	if len(options.GetGrpcCodes()) > 0 {
+		grpcCodes := make([]codes.Code, 0, len(options.GetGrpcCodes()))
+		for _, name := range options.GetGrpcCodes() {
+			var code codes.Code
+			if err := code.UnmarshalJSON([]byte(`"` + name + `"`)); err != nil {
+				return nil, fmt.Errorf("retry: invalid grpc code %q", name)
+			}
+			grpcCodes = append(grpcCodes, code)
+		}
+		opts = append(opts, WithGRPCCodes(grpcCodes...))
+	}
+	if len(options.GetIdempotentOperations()) > 0 {
+		for _, pattern := range options.GetIdempotentOperations() {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("retry: invalid idempotent operation %q: %v", pattern, err)
+			}
+		}
+		opts = append(opts, WithIdempotent(options.GetIdempotentOperations()...))
+	}
+	if b := options.GetBudget(); b != nil {
+		opts = append(opts, WithBudget(b.GetRatio(), int(b.GetMaxTokens())))
+	}
+
+	return Client(opts...), nil
+}
+
+// Option is retry option.
+type Option func(*Options)
+
+// Options is retry options.
+type Options struct {
+	attempts   int
+	base       time.Duration
+	max        time.Duration
+	multiplier float64
+	jitter     float64
+	reasons    map[string]struct{}
+	httpCodes  map[int]struct{}
+	grpcCodes  map[codes.Code]struct{}
+	idempotent []string
+	budget     *budget
+}
+
+// WithAttempts with the max attempts including the first call.
+func WithAttempts(attempts int) Option {
+	return func(o *Options) {
+		o.attempts = attempts
+	}
+}
+
+// WithBackoff with the exponential backoff, the zero values keep the defaults.
+func WithBackoff(base, max time.Duration, multiplier, jitter float64) Option {
+	return func(o *Options) {
+		if base > 0 {
+			o.base = base
+		}
+		if max > 0 {
+			o.max = max
+		}
+		if multiplier > 0 {
+			o.multiplier = multiplier
+		}
+		if jitter > 0 {
+			o.jitter = math.Min(jitter, 1)
+		}
+	}
+}
+
+// WithReasons with the retryable kratos error reasons.
+func WithReasons(reasons ...string) Option {
+	return func(o *Options) {
+		o.reasons = make(map[string]struct{}, len(reasons))
+		for _, reason := range reasons {
+			o.reasons[reason] = struct{}{}
+		}
+	}
+}
+
+// WithHTTPCodes with the retryable http status codes.
+func WithHTTPCodes(httpCodes ...int) Option {
+	return func(o *Options) {
+		o.httpCodes = make(map[int]struct{}, len(httpCodes))
+		for _, code := range httpCodes {
+			o.httpCodes[code] = struct{}{}
+		}
+	}
+}
+
+// WithGRPCCodes with the retryable grpc codes.
+func WithGRPCCodes(grpcCodes ...codes.Code) Option {
+	return func(o *Options) {
+		o.grpcCodes = make(map[codes.Code]struct{}, len(grpcCodes))
+		for _, code := range grpcCodes {
+			o.grpcCodes[code] = struct{}{}
+		}
+	}
+}
+
+// WithIdempotent with the glob patterns of the idempotent operations.
+func WithIdempotent(patterns ...string) Option {
+	return func(o *Options) {
+		o.idempotent = patterns
+	}
+}
+
+// WithBudget with the retry budget, each call deposits `ratio` tokens and each retry takes one.
+func WithBudget(ratio float64, maxTokens int) Option {
+	return func(o *Options) {
+		if ratio <= 0 {
+			ratio = 0.1
+		}
+		if maxTokens <= 0 {
+			maxTokens = 10
+		}
+		o.budget = newBudget(ratio, maxTokens)
+	}
+}
+
+// Client retries the failed idempotent calls with the retryable errors,
+// each retry excludes the nodes already tried.
+func Client(opts ...Option) middleware.Middleware {
+	options := Options{
+		attempts:   3,
+		base:       25 * time.Millisecond,
+		max:        time.Second,
+		multiplier: 2,
+		jitter:     0.2,
+		httpCodes: map[int]struct{}{
+			http.StatusBadGateway:         {},
+			http.StatusServiceUnavailable: {},
+			http.StatusGatewayTimeout:     {},
+		},
+		grpcCodes: map[codes.Code]struct{}{codes.Unavailable: {}},
+		budget:    newBudget(0.1, 10),
+	}
+	for _, o := range opts {
+		o(&options)
+	}
+
+	return func(handler middleware.Handler) middleware.Handler {
+		return func(ctx context.Context, req interface{}) (interface{}, error) {
+			options.budget.deposit()
+			if options.attempts <= 1 || !options.isIdempotent(ctx) {
+				return handler(ctx, req)
+			}
+
+			var tried []string
+			ctx = nodeselector.NewFilterContext(ctx, func(_ context.Context, nodes []selector.Node) []selector.Node {
+				return exclude(nodes, tried)
+			})
+			outer, _ := selector.FromPeerContext(ctx)
+
+			for attempt := 1; ; attempt++ {
+				var p selector.Peer
+				reply, err := handler(selector.NewPeerContext(ctx, &p), req)
+				if p.Node != nil {
+					tried = append(tried, p.Node.Address())
+					if outer != nil {
+						outer.Node = p.Node
+					}
+				}
+				if err == nil || attempt >= options.attempts || !options.isRetryable(ctx, err) || ctx.Err() != nil {
+					return reply, err
+				}
+				if !options.budget.withdraw() {
+					return reply, err
+				}
+
+				timer := time.NewTimer(options.backoff(attempt))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return reply, err
+				case <-timer.C:
+				}
+			}
+		}
+	}
+}
+
+// backoff returns the delay before the retry after `attempt` calls.
+func (o *Options) backoff(attempt int) time.Duration {
+	delay := float64(o.base) * math.Pow(o.multiplier, float64(attempt-1))
+	if delay > float64(o.max) {
+		delay = float64(o.max)
+	}
+	delay *= 1 + o.jitter*(rand.Float64()*2-1)
+	return time.Duration(delay)
+}
+
+// isRetryable reports whether the error has a retryable reason, or a retryable code of the transport:
+// the http codes for HTTP and the grpc codes for gRPC, the grpc codes are mapped to http codes by kratos,
+// e.g. DEADLINE_EXCEEDED is 504.
+func (o *Options) isRetryable(ctx context.Context, err error) bool {
+	e := errors.FromError(err)
+	if e == nil {
+		return false
+	}
+	if _, ok := o.reasons[e.Reason]; ok {
+		return true
+	}
+	var kind transport.Kind
+	if tr, ok := transport.FromClientContext(ctx); ok {
+		kind = tr.Kind()
+	}
+	if kind != transport.KindGRPC {
+		if _, ok := o.httpCodes[int(e.Code)]; ok {
+			return true
+		}
+	}
+	if kind != transport.KindHTTP {
+		if _, ok := o.grpcCodes[e.GRPCStatus().Code()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isIdempotent reports whether the operation matches the idempotent patterns,
+// or the http request method is idempotent.
+func (o *Options) isIdempotent(ctx context.Context) bool {
+	tr, ok := transport.FromClientContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, pattern := range o.idempotent {
+		if matched, _ := path.Match(pattern, tr.Operation()); matched {
+			return true
+		}
+	}
+	if ht, ok := tr.(interface{ Request() *http.Request }); ok && ht.Request() != nil {
+		switch ht.Request().Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// exclude returns the nodes not tried, all the nodes if all are tried.
+func exclude(nodes []selector.Node, tried []string) []selector.Node {
+	if len(tried) == 0 {
+		return nodes
+	}
+	out := make([]selector.Node, 0, len(nodes))
+	for _, node := range nodes {
+		found := false
+		for _, address := range tried {
+			if node.Address() == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			out = append(out, node)
+		}
+	}
+	if len(out) == 0 {
+		return nodes
+	}
+	return out
+}
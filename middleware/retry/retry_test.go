@@ -0,0 +1,176 @@
+package retry
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/go-kratos/kratos/v2/selector"
+	"github.com/go-kratos/kratos/v2/transport"
+	config "github.com/nextmicro/next/api/config/v1"
+	v1 "github.com/nextmicro/next/api/middleware/retry/v1"
+	chain "github.com/nextmicro/next/middleware"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+type Transport struct {
+	transport.Transporter
+	kind      transport.Kind
+	operation string
+	request   *http.Request
+}
+
+func (tr *Transport) Kind() transport.Kind {
+	return tr.kind
+}
+
+func (tr *Transport) Operation() string {
+	return tr.operation
+}
+
+func (tr *Transport) Request() *http.Request {
+	return tr.request
+}
+
+var nodes = []selector.Node{
+	selector.NewNode("grpc", "127.0.0.1:9000", &registry.ServiceInstance{}),
+	selector.NewNode("grpc", "127.0.0.1:9001", &registry.ServiceInstance{}),
+}
+
+// newHandler selects the first node left by the filters and fails the first `failures` calls.
+func newHandler(failures int, err error, selected *[]string) func(context.Context, interface{}) (interface{}, error) {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		candidates := nodes
+		for _, filter := range nodeselector.FiltersFromContext(ctx) {
+			candidates = filter(ctx, candidates)
+		}
+		p, _ := selector.FromPeerContext(ctx)
+		p.Node = candidates[0]
+		*selected = append(*selected, p.Node.Address())
+		if len(*selected) <= failures {
+			return nil, err
+		}
+		return "ok", nil
+	}
+}
+
+func TestClient(t *testing.T) {
+	m := Client(WithIdempotent("/helloworld.Greeter/Get*"), WithBackoff(time.Millisecond, 0, 0, 0))
+	newContext := func(operation string) context.Context {
+		ctx := transport.NewClientContext(context.Background(), &Transport{operation: operation})
+		return selector.NewPeerContext(ctx, &selector.Peer{})
+	}
+
+	// retried on another node
+	var selected []string
+	ctx := newContext("/helloworld.Greeter/GetUser")
+	reply, err := m(newHandler(1, errors.ServiceUnavailable("UNAVAILABLE", ""), &selected))(ctx, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+	assert.Equal(t, []string{"127.0.0.1:9000", "127.0.0.1:9001"}, selected)
+	p, _ := selector.FromPeerContext(ctx)
+	assert.Equal(t, "127.0.0.1:9001", p.Node.Address())
+
+	// max attempts
+	selected = nil
+	_, err = m(newHandler(5, errors.ServiceUnavailable("UNAVAILABLE", ""), &selected))(newContext("/helloworld.Greeter/GetUser"), nil)
+	assert.True(t, errors.IsServiceUnavailable(err))
+	assert.Len(t, selected, 3)
+
+	// not retryable error
+	selected = nil
+	_, err = m(newHandler(1, errors.BadRequest("BAD", ""), &selected))(newContext("/helloworld.Greeter/GetUser"), nil)
+	assert.True(t, errors.IsBadRequest(err))
+	assert.Len(t, selected, 1)
+
+	// not idempotent
+	selected = nil
+	_, err = m(newHandler(1, errors.ServiceUnavailable("UNAVAILABLE", ""), &selected))(newContext("/helloworld.Greeter/CreateUser"), nil)
+	assert.Error(t, err)
+	assert.Len(t, selected, 1)
+
+	// idempotent http method
+	selected = nil
+	req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+	ctx = transport.NewClientContext(context.Background(), &Transport{operation: "/users", request: req})
+	_, err = m(newHandler(1, errors.ServiceUnavailable("UNAVAILABLE", ""), &selected))(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+	assert.NoError(t, err)
+	assert.Len(t, selected, 2)
+}
+
+func TestClientCodes(t *testing.T) {
+	m := Client(WithIdempotent("*"), WithBackoff(time.Millisecond, 0, 0, 0))
+	call := func(kind transport.Kind, err error) []string {
+		var selected []string
+		ctx := transport.NewClientContext(context.Background(), &Transport{kind: kind, operation: "get"})
+		_, _ = m(newHandler(1, err, &selected))(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+		return selected
+	}
+
+	// DEADLINE_EXCEEDED is 504, only retried on HTTP
+	assert.Len(t, call(transport.KindGRPC, errors.FromError(status.Error(codes.DeadlineExceeded, ""))), 1)
+	assert.Len(t, call(transport.KindHTTP, errors.New(http.StatusGatewayTimeout, "", "")), 2)
+	assert.Len(t, call(transport.KindGRPC, errors.FromError(status.Error(codes.Unavailable, ""))), 2)
+}
+
+func TestClientBudget(t *testing.T) {
+	m := Client(WithIdempotent("*"), WithBudget(0.1, 1), WithBackoff(time.Millisecond, 0, 0, 0), WithReasons("RETRY"))
+	call := func() []string {
+		var selected []string
+		ctx := transport.NewClientContext(context.Background(), &Transport{operation: "get"})
+		_, _ = m(newHandler(5, errors.BadRequest("RETRY", ""), &selected))(selector.NewPeerContext(ctx, &selector.Peer{}), nil)
+		return selected
+	}
+
+	// the bucket has a single token
+	assert.Len(t, call(), 2)
+	assert.Len(t, call(), 1)
+}
+
+func TestBackoff(t *testing.T) {
+	o := &Options{base: 10 * time.Millisecond, max: 50 * time.Millisecond, multiplier: 2}
+	assert.Equal(t, 10*time.Millisecond, o.backoff(1))
+	assert.Equal(t, 40*time.Millisecond, o.backoff(3))
+	assert.Equal(t, 50*time.Millisecond, o.backoff(5))
+
+	o.jitter = 0.5
+	for i := 0; i < 10; i++ {
+		d := o.backoff(1)
+		assert.True(t, d >= 5*time.Millisecond && d <= 15*time.Millisecond, d)
+	}
+}
+
+func TestStream(t *testing.T) {
+	entries, err := chain.BuildStream("grpc.client", []*config.Middleware{{Name: "retry"}}, chain.Strict(true))
+	assert.NoError(t, err)
+	// a retry would create the stream again, the earlier one leaked
+	assert.Empty(t, entries)
+
+	var calls int
+	h := chain.StreamChain(chain.StreamMiddlewares(entries)...)(func(context.Context, chain.Stream) error {
+		calls++
+		return errors.ServiceUnavailable("UNAVAILABLE", "unavailable")
+	})
+	assert.Error(t, h(context.Background(), nil))
+	assert.Equal(t, 1, calls)
+}
+
+func TestInjection(t *testing.T) {
+	for _, options := range []*v1.Retry{
+		{Attempts: -1},
+		{Backoff: &v1.Backoff{Multiplier: -2}},
+		{Budget: &v1.Budget{Ratio: -0.1}},
+		{GrpcCodes: []string{"UNKNOWN_CODE"}},
+	} {
+		cfg, err := anypb.New(options)
+		assert.NoError(t, err)
+		assert.Error(t, chain.Validate("grpc.client", &config.Middleware{Name: "retry", Options: cfg}), options)
+	}
+}
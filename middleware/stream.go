@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	configv1 "github.com/nextmicro/next/api/config/v1"
+)
+
+var globalStreamRegistry = NewStreamRegistry()
+
+var (
+	// ErrStreamUnsupported is the unary middleware not marked as stream safe, it only runs for the unary RPCs.
+	ErrStreamUnsupported = errors.New("Middleware does not support streams")
+	// ErrStreamHandlerCalled is returned when the adapted unary middleware calls the handler more than once,
+	// e.g. retrying or hedging the whole stream.
+	ErrStreamHandlerCalled = errors.New("stream handler called more than once")
+)
+
+var (
+	streamSafeMu sync.RWMutex
+	streamSafe   = make(map[string]struct{})
+)
+
+// Stream is the message stream of a streaming RPC,
+// it is implemented by both grpc.ServerStream and grpc.ClientStream.
+type Stream interface {
+	// Context returns the context of the stream.
+	Context() context.Context
+	// SendMsg sends a message.
+	SendMsg(m interface{}) error
+	// RecvMsg receives a message.
+	RecvMsg(m interface{}) error
+}
+
+// StreamHandler defines the handler invoked by StreamMiddleware.
+// The handler returns when the whole stream is finished.
+type StreamHandler func(ctx context.Context, stream Stream) error
+
+// StreamMiddleware is the stream transport middleware.
+type StreamMiddleware func(StreamHandler) StreamHandler
+
+// StreamChain returns a StreamMiddleware that specifies the chained handler for streams.
+func StreamChain(m ...StreamMiddleware) StreamMiddleware {
+	return func(next StreamHandler) StreamHandler {
+		for i := len(m) - 1; i >= 0; i-- {
+			next = m[i](next)
+		}
+		return next
+	}
+}
+
+// UnaryToStream adapts a unary middleware to streams,
+// the middleware runs once around the whole stream with a nil request.
+// The stream can only be handled once, the later calls of the handler return ErrStreamHandlerCalled.
+func UnaryToStream(m middleware.Middleware) StreamMiddleware {
+	return func(next StreamHandler) StreamHandler {
+		return func(ctx context.Context, stream Stream) error {
+			var called atomic.Bool
+			h := m(func(ctx context.Context, _ interface{}) (interface{}, error) {
+				if !called.CompareAndSwap(false, true) {
+					return nil, ErrStreamHandlerCalled
+				}
+				return nil, next(ctx, WrapStream(stream, WithStreamContext(ctx)))
+			})
+			_, err := h(ctx, nil)
+			return err
+		}
+	}
+}
+
+// StreamOption is a wrapped stream option.
+type StreamOption func(*wrappedStream)
+
+// WithStreamContext overrides the context of the stream.
+func WithStreamContext(ctx context.Context) StreamOption {
+	return func(w *wrappedStream) {
+		w.ctx = ctx
+	}
+}
+
+// OnRecvMsg registers a hook called after each RecvMsg.
+func OnRecvMsg(fn func(m interface{}, err error)) StreamOption {
+	return func(w *wrappedStream) {
+		w.onRecv = fn
+	}
+}
+
+// OnSendMsg registers a hook called after each SendMsg.
+func OnSendMsg(fn func(m interface{}, err error)) StreamOption {
+	return func(w *wrappedStream) {
+		w.onSend = fn
+	}
+}
+
+// WrapStream wraps the stream with the per-message hooks.
+func WrapStream(stream Stream, opts ...StreamOption) Stream {
+	w := &wrappedStream{Stream: stream}
+	for _, o := range opts {
+		o(w)
+	}
+	return w
+}
+
+type wrappedStream struct {
+	Stream
+	ctx    context.Context
+	onRecv func(m interface{}, err error)
+	onSend func(m interface{}, err error)
+}
+
+func (w *wrappedStream) Context() context.Context {
+	if w.ctx != nil {
+		return w.ctx
+	}
+	return w.Stream.Context()
+}
+
+func (w *wrappedStream) SendMsg(m interface{}) error {
+	err := w.Stream.SendMsg(m)
+	if w.onSend != nil {
+		w.onSend(m, err)
+	}
+	return err
+}
+
+func (w *wrappedStream) RecvMsg(m interface{}) error {
+	err := w.Stream.RecvMsg(m)
+	if w.onRecv != nil {
+		w.onRecv(m, err)
+	}
+	return err
+}
+
+// StreamFactory is a stream middleware factory.
+type StreamFactory func(*configv1.Middleware) (StreamMiddleware, error)
+
+// StreamRegistry is the interface for callers to get registered stream middleware.
+type StreamRegistry interface {
+	Register(name string, factory StreamFactory)
+	Create(cfg *configv1.Middleware) (StreamMiddleware, error)
+	Has(name string) bool
+}
+
+type streamRegistry struct {
+	lock       sync.RWMutex
+	middleware map[string]StreamFactory
+}
+
+// NewStreamRegistry returns a new stream middleware registry.
+func NewStreamRegistry() StreamRegistry {
+	return &streamRegistry{
+		middleware: map[string]StreamFactory{},
+	}
+}
+
+// Register registers one stream middleware.
+func (p *streamRegistry) Register(name string, factory StreamFactory) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.middleware[createFullName(name)] = factory
+}
+
+// Create instantiates a stream middleware based on `cfg`.
+func (p *streamRegistry) Create(cfg *configv1.Middleware) (StreamMiddleware, error) {
+	p.lock.RLock()
+	factory, ok := p.middleware[createFullName(cfg.Name)]
+	p.lock.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return factory(cfg)
+}
+
+// Has reports whether a stream middleware is registered with name.
+func (p *streamRegistry) Has(name string) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, ok := p.middleware[createFullName(name)]
+	return ok
+}
+
+// RegisterStream registers one stream middleware.
+func RegisterStream(name string, factory StreamFactory) {
+	globalStreamRegistry.Register(name, factory)
+}
+
+// RegisterStreamSafe marks the unary middlewares registered with names as safe to run around the whole stream,
+// they call the handler at most once and do not depend on the request. See CreateStream.
+func RegisterStreamSafe(names ...string) {
+	streamSafeMu.Lock()
+	defer streamSafeMu.Unlock()
+	for _, name := range names {
+		streamSafe[createFullName(name)] = struct{}{}
+	}
+}
+
+// IsStreamSafe reports whether the unary middleware registered with name is marked as stream safe.
+func IsStreamSafe(name string) bool {
+	streamSafeMu.RLock()
+	defer streamSafeMu.RUnlock()
+	_, ok := streamSafe[createFullName(name)]
+	return ok
+}
+
+// CreateStream instantiates a stream middleware based on `cfg`.
+// The unary middleware marked by RegisterStreamSafe is adapted by UnaryToStream if no stream middleware
+// is registered with the name, the others return ErrStreamUnsupported.
+func CreateStream(cfg *configv1.Middleware) (StreamMiddleware, error) {
+	m, _, err := createStream(cfg)
+	return m, err
+}
+
+// createStream instantiates a stream middleware based on `cfg` with the close of the adapted unary middleware.
+func createStream(cfg *configv1.Middleware) (StreamMiddleware, CloseFunc, error) {
+	m, err := globalStreamRegistry.Create(cfg)
+	if !errors.Is(err, ErrNotFound) {
+		return m, nil, err
+	}
+	if Has(cfg.Name) && !IsStreamSafe(cfg.Name) {
+		return nil, nil, ErrStreamUnsupported
+	}
+
+	um, closeFn, err := createCloser(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return UnaryToStream(um), closeFn, nil
+}
+
+// HasStream reports whether a stream middleware is registered with name.
+func HasStream(name string) bool {
+	return globalStreamRegistry.Has(name)
+}
+
+// BuildStreamMiddleware builds the stream middlewares configured in `_ms`, in the same order as BuildMiddleware.
+func BuildStreamMiddleware(kind string, _ms []*configv1.Middleware, opts ...BuildOption) ([]StreamMiddleware, error) {
+	entries, err := BuildStream(kind, _ms, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return StreamMiddlewares(entries), nil
+}
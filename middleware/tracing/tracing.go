@@ -31,6 +31,7 @@ const (
 func init() {
 	chain.Register("client.tracing", injectionClient)
 	chain.Register("server.tracing", injectionServer)
+	chain.RegisterStreamSafe("client.tracing", "server.tracing")
 }
 
 func injectionClient(c *configv1.Middleware) (middleware.Middleware, error) {
@@ -2,31 +2,46 @@ package next
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	prom "github.com/go-kratos/kratos/contrib/metrics/prometheus/v2"
 	"github.com/nextmicro/next/config"
 	"github.com/nextmicro/next/pkg/env"
+	"github.com/nextmicro/next/pkg/health"
 	metric "github.com/nextmicro/next/pkg/metrics"
 	"github.com/nextmicro/next/registry"
+	"github.com/nextmicro/next/transport/admin"
 
 	"github.com/go-kratos/kratos/v2"
+	_ "github.com/nextmicro/next/middleware/auth/jwt"
 	_ "github.com/nextmicro/next/middleware/bbr"
 	_ "github.com/nextmicro/next/middleware/circuitbreaker"
+	_ "github.com/nextmicro/next/middleware/cors"
+	_ "github.com/nextmicro/next/middleware/fault"
+	_ "github.com/nextmicro/next/middleware/hedging"
+	_ "github.com/nextmicro/next/middleware/hmac"
 	_ "github.com/nextmicro/next/middleware/logging"
 	_ "github.com/nextmicro/next/middleware/metadata"
 	_ "github.com/nextmicro/next/middleware/metrics"
+	_ "github.com/nextmicro/next/middleware/ratelimit"
+	_ "github.com/nextmicro/next/middleware/rbac"
 	_ "github.com/nextmicro/next/middleware/recovery"
+	_ "github.com/nextmicro/next/middleware/requestid"
+	_ "github.com/nextmicro/next/middleware/retry"
 	_ "github.com/nextmicro/next/middleware/tracing"
 	"github.com/nextmicro/next/runtime"
 )
 
 type Next struct {
 	*kratos.App
-	opt Options
+	opt       Options
+	ready     atomic.Bool
+	registrar *drainRegistrar
 }
 
 // New create an application lifecycle manager.
@@ -49,6 +64,24 @@ func New(opts ...Option) (*Next, error) {
 	// register runtime stop
 	opt.AfterStop = append(opt.AfterStop, run.Stop)
 
+	next := &Next{opt: opt}
+
+	// admin server
+	if srv := next.buildAdminServer(run.Health()); srv != nil {
+		opt.Servers = append(opt.Servers, srv)
+	}
+
+	// the instance is deregistered by the drain before the servers stop
+	if opt.Registrar != nil {
+		next.registrar = &drainRegistrar{Registrar: opt.Registrar}
+		opt.Registrar = next.registrar
+	}
+	opt.AfterStart = append(opt.AfterStart, func(context.Context) error {
+		next.ready.Store(true)
+		return nil
+	})
+	opt.BeforeStop = append([]func(context.Context) error{next.drain}, opt.BeforeStop...)
+
 	kOpts := []kratos.Option{
 		kratos.ID(opt.ID),
 		kratos.Name(opt.Name),
@@ -75,10 +108,8 @@ func New(opts ...Option) (*Next, error) {
 		kOpts = append(kOpts, kratos.AfterStop(afterStop))
 	}
 
-	next := &Next{
-		App: kratos.New(kOpts...),
-		opt: opt,
-	}
+	next.App = kratos.New(kOpts...)
+	next.opt = opt
 
 	// init metrics
 	next.initMetrics()
@@ -100,6 +131,36 @@ func (app *Next) initMetrics() {
 	).Set(float64(time.Now().UnixNano() / 1e6))
 }
 
+// buildAdminServer builds the admin server from `server.admin`, nil if it is not configured or disabled.
+// The service is ready after started and all the health checks passed.
+func (app *Next) buildAdminServer(h *health.Health) *admin.Server {
+	c := config.ApplicationConfig().GetServer().GetAdmin()
+	if c == nil || c.GetDisable() {
+		return nil
+	}
+
+	opts := []admin.ServerOption{
+		admin.Service(app.opt.ID, app.opt.Name, app.opt.Version),
+		admin.Readiness(func(ctx context.Context) error {
+			if !app.ready.Load() {
+				return errors.New("service is not ready")
+			}
+			if h == nil {
+				return nil
+			}
+			return h.CheckService(ctx, "")
+		}),
+		admin.ConfigOrigins(config.Origins),
+	}
+	if c.GetNetwork() != "" {
+		opts = append(opts, admin.Network(c.GetNetwork()))
+	}
+	if c.GetAddr() != "" {
+		opts = append(opts, admin.Address(c.GetAddr()))
+	}
+	return admin.NewServer(opts...)
+}
+
 // buildOptions build options
 func buildOptions(options ...Option) Options {
 	var opts = make([]Option, 0, len(options)+4)
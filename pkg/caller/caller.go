@@ -0,0 +1,80 @@
+package caller
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/nextmicro/next/config"
+)
+
+const (
+	// NameKey is the metadata key of the caller service name.
+	NameKey = "x-md-local-caller"
+	// VersionKey is the metadata key of the caller service version.
+	VersionKey = "x-md-local-caller-version"
+	// IDKey is the metadata key of the caller instance id.
+	IDKey = "x-md-local-caller-id"
+)
+
+// Caller is the identity of the service which sends the request.
+type Caller struct {
+	ID      string
+	Name    string
+	Version string
+}
+
+type callerKey struct{}
+
+// NewContext returns a new context with the caller.
+func NewContext(ctx context.Context, c Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, c)
+}
+
+// FromContext returns the caller of the server request.
+func FromContext(ctx context.Context) (c Caller, ok bool) {
+	c, ok = ctx.Value(callerKey{}).(Caller)
+	return
+}
+
+// Local returns the identity of the current service,
+// it is read from config.ApplicationConfig() and falls back to the kratos app info in `ctx`.
+func Local(ctx context.Context) Caller {
+	var c Caller
+	if cfg := config.ApplicationConfig(); cfg != nil {
+		c = Caller{ID: cfg.GetId(), Name: cfg.GetName(), Version: cfg.GetVersion()}
+	}
+	if app, ok := kratos.FromContext(ctx); ok {
+		if c.ID == "" {
+			c.ID = app.ID()
+		}
+		if c.Name == "" {
+			c.Name = app.Name()
+		}
+		if c.Version == "" {
+			c.Version = app.Version()
+		}
+	}
+	return c
+}
+
+// Inject sets the local identity into the request header,
+// the keys already set by the user are kept.
+func Inject(ctx context.Context, header transport.Header) {
+	c := Local(ctx)
+	for k, v := range map[string]string{NameKey: c.Name, VersionKey: c.Version, IDKey: c.ID} {
+		if v != "" && header.Get(k) == "" {
+			header.Set(k, v)
+		}
+	}
+}
+
+// Extract parses the caller from the request header.
+func Extract(header transport.Header) (Caller, bool) {
+	c := Caller{
+		ID:      header.Get(IDKey),
+		Name:    header.Get(NameKey),
+		Version: header.Get(VersionKey),
+	}
+	return c, c.Name != ""
+}
@@ -0,0 +1,62 @@
+package caller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2"
+)
+
+type header map[string]string
+
+func (h header) Get(key string) string      { return h[key] }
+func (h header) Set(key, value string)      { h[key] = value }
+func (h header) Add(key, value string)      { h[key] = value }
+func (h header) Values(key string) []string { return []string{h[key]} }
+func (h header) Keys() []string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func TestInjectExtract(t *testing.T) {
+	app := kratos.New(kratos.ID("1"), kratos.Name("greeter"), kratos.Version("v1.0.0"))
+	ctx := kratos.NewContext(context.Background(), app)
+
+	h := header{}
+	Inject(ctx, h)
+	c, ok := Extract(h)
+	if !ok {
+		t.Fatal("expected caller in header")
+	}
+	expect := Caller{ID: "1", Name: "greeter", Version: "v1.0.0"}
+	if c != expect {
+		t.Fatalf("expected %+v got %+v", expect, c)
+	}
+
+	// keep the caller set by the user
+	h = header{NameKey: "custom"}
+	Inject(ctx, h)
+	if v := h.Get(NameKey); v != "custom" {
+		t.Fatalf("expected custom got %s", v)
+	}
+}
+
+func TestExtractEmpty(t *testing.T) {
+	if _, ok := Extract(header{}); ok {
+		t.Fatal("expected no caller")
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatal("expected no caller")
+	}
+	expect := Caller{Name: "greeter"}
+	c, ok := FromContext(NewContext(context.Background(), expect))
+	if !ok || c != expect {
+		t.Fatalf("expected %+v got %+v", expect, c)
+	}
+}
@@ -0,0 +1,237 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout = time.Second
+	defaultTTL     = time.Second
+)
+
+// DefaultHealth is the default health aggregator, the runtime registers the loaders into it.
+var DefaultHealth = New()
+
+// Checker checks the health of a dependency, a nil error means healthy.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc is an adapter to allow the use of ordinary functions as Checker.
+type CheckerFunc func(ctx context.Context) error
+
+// Check calls f(ctx).
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result is the result of one check.
+type Result struct {
+	Name      string        `json:"name"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	CheckedAt time.Time     `json:"checked_at"`
+
+	err error
+}
+
+// Err returns the check error.
+func (r Result) Err() error {
+	return r.err
+}
+
+// Option is a health option.
+type Option func(*Health)
+
+// WithTimeout with the default timeout of each check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(h *Health) {
+		h.timeout = timeout
+	}
+}
+
+// WithCacheTTL with the duration the check results are cached.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(h *Health) {
+		h.ttl = ttl
+	}
+}
+
+// CheckOption is a check option.
+type CheckOption func(*check)
+
+// Timeout with the check timeout.
+func Timeout(timeout time.Duration) CheckOption {
+	return func(c *check) {
+		c.timeout = timeout
+	}
+}
+
+// Services with the gRPC services the check affects, all services are affected by default.
+func Services(services ...string) CheckOption {
+	return func(c *check) {
+		c.services = services
+	}
+}
+
+type check struct {
+	name     string
+	checker  Checker
+	timeout  time.Duration
+	services []string
+
+	mu     sync.Mutex
+	result *Result
+}
+
+func (c *check) affects(service string) bool {
+	if len(c.services) == 0 || service == "" {
+		return true
+	}
+	for _, s := range c.services {
+		if s == service {
+			return true
+		}
+	}
+	return false
+}
+
+// run runs the check, the result is cached for `ttl`.
+func (c *check) run(ctx context.Context, ttl time.Duration) Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.result != nil && time.Since(c.result.CheckedAt) < ttl {
+		return *c.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errc := make(chan error, 1)
+	go func() {
+		errc <- c.checker.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timeout after %s", c.timeout)
+	}
+
+	r := Result{Name: c.name, Duration: time.Since(start), CheckedAt: time.Now(), err: err}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	c.result = &r
+	return r
+}
+
+// Health aggregates the health checkers.
+type Health struct {
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]*check
+}
+
+// New creates a health aggregator.
+func New(opts ...Option) *Health {
+	h := &Health{
+		timeout: defaultTimeout,
+		ttl:     defaultTTL,
+		checks:  make(map[string]*check),
+	}
+	for _, o := range opts {
+		o(h)
+	}
+	return h
+}
+
+// Register registers a checker, the checker with the same name is replaced.
+func (h *Health) Register(name string, checker Checker, opts ...CheckOption) {
+	c := &check{name: name, checker: checker, timeout: h.timeout}
+	for _, o := range opts {
+		o(c)
+	}
+
+	h.mu.Lock()
+	h.checks[name] = c
+	h.mu.Unlock()
+}
+
+// Unregister removes the checker.
+func (h *Health) Unregister(name string) {
+	h.mu.Lock()
+	delete(h.checks, name)
+	h.mu.Unlock()
+}
+
+// Check runs all checkers concurrently and returns the results sorted by name.
+func (h *Health) Check(ctx context.Context) []Result {
+	return h.check(ctx, "")
+}
+
+// CheckService returns the aggregated error of the checkers affecting the gRPC service,
+// an empty service means the whole server.
+func (h *Health) CheckService(ctx context.Context, service string) error {
+	return Aggregate(h.check(ctx, service))
+}
+
+func (h *Health) check(ctx context.Context, service string) []Result {
+	h.mu.RLock()
+	checks := make([]*check, 0, len(h.checks))
+	for _, c := range h.checks {
+		if c.affects(service) {
+			checks = append(checks, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func(i int, c *check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, h.ttl)
+		}(i, c)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	return results
+}
+
+// Aggregate returns an error describing the failed checks, nil if all checks passed.
+func Aggregate(results []Result) error {
+	var failed []string
+	for _, r := range results {
+		if r.err != nil {
+			failed = append(failed, r.Name+": "+r.Error)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("health check failed: %s", strings.Join(failed, "; "))
+}
+
+// Register registers a checker into the DefaultHealth.
+func Register(name string, checker Checker, opts ...CheckOption) {
+	DefaultHealth.Register(name, checker, opts...)
+}
+
+// Unregister removes the checker from the DefaultHealth.
+func Unregister(name string) {
+	DefaultHealth.Unregister(name)
+}
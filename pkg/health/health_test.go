@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	h := New()
+	h.Register("ok", CheckerFunc(func(context.Context) error { return nil }))
+	h.Register("mysql", CheckerFunc(func(context.Context) error { return errors.New("connection refused") }),
+		Services("helloworld.Greeter"))
+
+	results := h.Check(context.Background())
+	if len(results) != 2 || results[0].Name != "mysql" || results[1].Name != "ok" {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if results[0].Err() == nil || results[1].Err() != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if err := h.CheckService(context.Background(), ""); err == nil {
+		t.Fatal("expected server not healthy")
+	}
+	if err := h.CheckService(context.Background(), "helloworld.Greeter"); err == nil {
+		t.Fatal("expected helloworld.Greeter not healthy")
+	}
+	if err := h.CheckService(context.Background(), "helloworld.Other"); err != nil {
+		t.Fatalf("expected helloworld.Other healthy, got %v", err)
+	}
+
+	h.Unregister("mysql")
+	if err := h.CheckService(context.Background(), ""); err != nil {
+		t.Fatalf("expected healthy, got %v", err)
+	}
+}
+
+func TestHealthCache(t *testing.T) {
+	var count int64
+	h := New(WithCacheTTL(time.Hour))
+	h.Register("counter", CheckerFunc(func(context.Context) error {
+		atomic.AddInt64(&count, 1)
+		return nil
+	}))
+
+	for i := 0; i < 3; i++ {
+		h.Check(context.Background())
+	}
+	if v := atomic.LoadInt64(&count); v != 1 {
+		t.Fatalf("expected 1 check, got %d", v)
+	}
+}
+
+func TestHealthTimeout(t *testing.T) {
+	h := New(WithTimeout(time.Second))
+	h.Register("slow", CheckerFunc(func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}), Timeout(10*time.Millisecond))
+
+	start := time.Now()
+	if err := h.CheckService(context.Background(), ""); err == nil {
+		t.Fatal("expected timeout")
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("expected check to time out quickly, took %s", d)
+	}
+}
@@ -48,6 +48,22 @@ var (
 		Help:      "The total number of processed requests",
 	}, []string{"kind", "caller", "method", "status"})
 
+	// ClientMetricStreamMessages is a counter vector of stream messages.
+	ClientMetricStreamMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: DefaultNamespace,
+		Subsystem: "client_stream",
+		Name:      "messages_total",
+		Help:      "The total number of stream messages sent and received",
+	}, []string{"kind", "callee", "method", "type"})
+
+	// ServerMetricStreamMessages is a counter vector of stream messages.
+	ServerMetricStreamMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: DefaultNamespace,
+		Subsystem: "server_stream",
+		Name:      "messages_total",
+		Help:      "The total number of stream messages sent and received",
+	}, []string{"kind", "caller", "method", "type"})
+
 	// MetricRateLimitTotal is a counter vector of rate limit.
 	MetricRateLimitTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Namespace: DefaultNamespace,
@@ -56,6 +72,30 @@ var (
 		Help:      "The total number of ratelimit denied requests",
 	}, []string{"kind", "caller", "method"})
 
+	// MetricRBACDeniedTotal is a counter vector of the requests denied by rbac.
+	MetricRBACDeniedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: DefaultNamespace,
+		Subsystem: "requests_rbac",
+		Name:      "denied_total",
+		Help:      "The total number of rbac denied requests",
+	}, []string{"kind", "caller", "method"})
+
+	// ClientMetricCircuitBreakerState is a gauge vector of the circuit breaker state, 0: closed, 1: open, 2: half-open.
+	ClientMetricCircuitBreakerState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: DefaultNamespace,
+		Subsystem: "client_circuitbreaker",
+		Name:      "state",
+		Help:      "The state of the circuit breaker, 0: closed, 1: open, 2: half-open",
+	}, []string{"operation", "node"})
+
+	// ClientMetricCircuitBreakerTransitions is a counter vector of the circuit breaker state transitions.
+	ClientMetricCircuitBreakerTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: DefaultNamespace,
+		Subsystem: "client_circuitbreaker",
+		Name:      "transitions_total",
+		Help:      "The total number of circuit breaker state transitions",
+	}, []string{"operation", "node", "from", "to"})
+
 	// DBSystemMetricMillisecond is a prometheus histogram for measuring the duration of a request.
 	DBSystemMetricMillisecond = prometheus.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: ComponentNamespace,
@@ -118,8 +158,11 @@ var (
 func init() {
 	prometheus.MustRegister(
 		MetricRateLimitTotal,
+		MetricRBACDeniedTotal,
 		ClientMetricMillisecond, ClientMetricRequests, // client metrics
 		ServerMetricMillisecond, ServerMetricRequests, // server metrics
+		ClientMetricStreamMessages, ServerMetricStreamMessages, // stream metrics
+		ClientMetricCircuitBreakerState, ClientMetricCircuitBreakerTransitions, // circuit breaker metrics
 		DBSystemMetricMillisecond, DBSystemMetricRequests, // db client metrics
 		MessagingProducerMetricMillisecond, MessagingProducerMetricRequests, // messaging producer
 		MessagingConsumerMetricMillisecond, MessagingConsumerMetricRequests, // messaging consumer
@@ -0,0 +1,119 @@
+package redact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nextmicro/logger"
+)
+
+// loggerWrapper masks the marked secrets in the messages and the string fields of the logger.
+type loggerWrapper struct {
+	logger.Logger
+}
+
+// WrapLogger returns a logger which masks the marked secrets, see String.
+func WrapLogger(l logger.Logger) logger.Logger {
+	if _, ok := l.(*loggerWrapper); ok {
+		return l
+	}
+	return &loggerWrapper{Logger: l}
+}
+
+func (l *loggerWrapper) WithContext(ctx context.Context) logger.Logger {
+	return &loggerWrapper{Logger: l.Logger.WithContext(ctx)}
+}
+
+func (l *loggerWrapper) WithFields(fields map[string]any) logger.Logger {
+	masked := make(map[string]any, len(fields))
+	for k, v := range fields {
+		masked[k] = value(v)
+	}
+	return &loggerWrapper{Logger: l.Logger.WithFields(masked)}
+}
+
+func (l *loggerWrapper) WithCallDepth(callDepth int) logger.Logger {
+	return &loggerWrapper{Logger: l.Logger.WithCallDepth(callDepth)}
+}
+
+func (l *loggerWrapper) Debug(args ...interface{}) {
+	l.Logger.WithCallDepth(1).Debug(String(fmt.Sprint(args...)))
+}
+
+func (l *loggerWrapper) Info(args ...interface{}) {
+	l.Logger.WithCallDepth(1).Info(String(fmt.Sprint(args...)))
+}
+
+func (l *loggerWrapper) Warn(args ...interface{}) {
+	l.Logger.WithCallDepth(1).Warn(String(fmt.Sprint(args...)))
+}
+
+func (l *loggerWrapper) Error(args ...interface{}) {
+	l.Logger.WithCallDepth(1).Error(String(fmt.Sprint(args...)))
+}
+
+func (l *loggerWrapper) Fatal(args ...interface{}) {
+	l.Logger.WithCallDepth(1).Fatal(String(fmt.Sprint(args...)))
+}
+
+func (l *loggerWrapper) Debugf(template string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Debug(String(fmt.Sprintf(template, args...)))
+}
+
+func (l *loggerWrapper) Infof(template string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Info(String(fmt.Sprintf(template, args...)))
+}
+
+func (l *loggerWrapper) Warnf(template string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Warn(String(fmt.Sprintf(template, args...)))
+}
+
+func (l *loggerWrapper) Errorf(template string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Error(String(fmt.Sprintf(template, args...)))
+}
+
+func (l *loggerWrapper) Fatalf(template string, args ...interface{}) {
+	l.Logger.WithCallDepth(1).Fatal(String(fmt.Sprintf(template, args...)))
+}
+
+func (l *loggerWrapper) Debugw(msg string, keysAndValues ...interface{}) {
+	l.Logger.WithCallDepth(1).Debugw(String(msg), values(keysAndValues)...)
+}
+
+func (l *loggerWrapper) Infow(msg string, keysAndValues ...interface{}) {
+	l.Logger.WithCallDepth(1).Infow(String(msg), values(keysAndValues)...)
+}
+
+func (l *loggerWrapper) Warnw(msg string, keysAndValues ...interface{}) {
+	l.Logger.WithCallDepth(1).Warnw(String(msg), values(keysAndValues)...)
+}
+
+func (l *loggerWrapper) Errorw(msg string, keysAndValues ...interface{}) {
+	l.Logger.WithCallDepth(1).Errorw(String(msg), values(keysAndValues)...)
+}
+
+func (l *loggerWrapper) Fatalw(msg string, keysAndValues ...interface{}) {
+	l.Logger.WithCallDepth(1).Fatalw(String(msg), values(keysAndValues)...)
+}
+
+// values masks the string values of the key value pairs.
+func values(keysAndValues []interface{}) []interface{} {
+	masked := make([]interface{}, len(keysAndValues))
+	for i, v := range keysAndValues {
+		if i%2 == 1 {
+			v = value(v)
+		}
+		masked[i] = v
+	}
+	return masked
+}
+
+func value(v interface{}) interface{} {
+	switch s := v.(type) {
+	case string:
+		return String(s)
+	case error:
+		return String(s.Error())
+	}
+	return v
+}
@@ -0,0 +1,60 @@
+package redact
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask replaces the secrets.
+const Mask = "******"
+
+// minLength is the minimum length of the marked secrets,
+// the shorter ones would mask too much of the unrelated text.
+const minLength = 4
+
+var (
+	mu      sync.RWMutex
+	secrets = make(map[string]struct{})
+	// sorted is the secrets with the longest first, so a secret containing the other one is masked as a whole.
+	sorted []string
+)
+
+// Mark marks the values as secrets, they are masked by String.
+func Mark(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, v := range values {
+		if _, ok := secrets[v]; ok || len(v) < minLength {
+			continue
+		}
+		secrets[v] = struct{}{}
+		sorted = append(sorted, v)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+}
+
+// IsSecret reports whether the value is marked as secret.
+func IsSecret(value string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, ok := secrets[value]
+	return ok
+}
+
+// String masks the marked secrets in s.
+func String(s string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, secret := range sorted {
+		if strings.Contains(s, secret) {
+			s = strings.ReplaceAll(s, secret, Mask)
+		}
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package redact
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nextmicro/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	Mark("s3cr3t-password", "abc", "")
+	assert.True(t, IsSecret("s3cr3t-password"))
+	assert.False(t, IsSecret("abc"))
+
+	assert.Equal(t, "dsn: root:******@tcp(127.0.0.1:3306)/abc", String("dsn: root:s3cr3t-password@tcp(127.0.0.1:3306)/abc"))
+	assert.Equal(t, "nothing", String("nothing"))
+
+	// the longer secret containing the other one is masked as a whole
+	Mark("s3cr3t", "s3cr3t-password-2")
+	assert.Equal(t, "a=******,b=******", String("a=s3cr3t-password-2,b=s3cr3t"))
+}
+
+func TestWrapLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := WrapLogger(logger.New(logger.WithWriter(&buf), logger.WithEncoder(logger.JsonEncoder)))
+	assert.Same(t, l, WrapLogger(l))
+
+	Mark("logged-s3cr3t")
+	l.Infof("dsn: root:%s@tcp", "logged-s3cr3t")
+	l.WithFields(map[string]any{"password": "logged-s3cr3t"}).Infow("connect", "error", errors.New("auth logged-s3cr3t"))
+	assert.NotContains(t, buf.String(), "logged-s3cr3t")
+	assert.Contains(t, buf.String(), "dsn: root:******@tcp")
+	assert.Contains(t, buf.String(), "redact/redact_test.go")
+}
@@ -0,0 +1,87 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/google/uuid"
+	"github.com/nextmicro/logger"
+)
+
+const (
+	// HeaderKey is the request header and response header of the request id.
+	HeaderKey = "X-Request-ID"
+	// MetadataKey is the metadata key of the request id, it is propagated by the metadata middleware.
+	MetadataKey = "x-md-global-request-id"
+	// LogKey is the log field of the request id.
+	LogKey = "request_id"
+)
+
+type requestIDKey struct{}
+
+// NewContext returns a new context with the request id.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns the request id in ctx, or in the server metadata of ctx.
+func FromContext(ctx context.Context) (string, bool) {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok && id != "" {
+		return id, true
+	}
+	if md, ok := metadata.FromServerContext(ctx); ok {
+		if id := md.Get(MetadataKey); id != "" {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// New generates a new request id.
+func New() string {
+	return uuid.NewString()
+}
+
+// Inject sets the request id of ctx into the header, the id already set is kept.
+func Inject(ctx context.Context, header map[string]string) {
+	if id, ok := FromContext(ctx); ok && header[HeaderKey] == "" {
+		header[HeaderKey] = id
+	}
+}
+
+// Extract returns a new context with the request id of the header.
+func Extract(ctx context.Context, header map[string]string) context.Context {
+	if id := header[HeaderKey]; id != "" {
+		return NewContext(ctx, id)
+	}
+	return ctx
+}
+
+// loggerWrapper injects the request id into the logger of WithContext.
+type loggerWrapper struct {
+	logger.Logger
+}
+
+// WrapLogger returns a logger which adds the request id of the context to the fields of WithContext.
+func WrapLogger(l logger.Logger) logger.Logger {
+	if _, ok := l.(*loggerWrapper); ok {
+		return l
+	}
+	return &loggerWrapper{Logger: l}
+}
+
+func (l *loggerWrapper) WithContext(ctx context.Context) logger.Logger {
+	base := l.Logger.WithContext(ctx)
+	if id, ok := FromContext(ctx); ok {
+		base = base.WithFields(map[string]any{LogKey: id})
+	}
+	return &loggerWrapper{Logger: base}
+}
+
+func (l *loggerWrapper) WithFields(fields map[string]any) logger.Logger {
+	return &loggerWrapper{Logger: l.Logger.WithFields(fields)}
+}
+
+func (l *loggerWrapper) WithCallDepth(callDepth int) logger.Logger {
+	return &loggerWrapper{Logger: l.Logger.WithCallDepth(callDepth)}
+}
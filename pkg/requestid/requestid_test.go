@@ -0,0 +1,47 @@
+package requestid
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	"github.com/nextmicro/logger"
+)
+
+func TestFromContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatal("expected no request id")
+	}
+	if id, _ := FromContext(NewContext(context.Background(), "abc")); id != "abc" {
+		t.Fatalf("expected abc got %q", id)
+	}
+	ctx := metadata.NewServerContext(context.Background(), metadata.New(map[string][]string{MetadataKey: {"def"}}))
+	if id, _ := FromContext(ctx); id != "def" {
+		t.Fatalf("expected def got %q", id)
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	header := map[string]string{}
+	Inject(NewContext(context.Background(), "abc"), header)
+	if header[HeaderKey] != "abc" {
+		t.Fatalf("expected abc got %q", header[HeaderKey])
+	}
+	if id, _ := FromContext(Extract(context.Background(), header)); id != "abc" {
+		t.Fatalf("expected abc got %q", id)
+	}
+}
+
+func TestWrapLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := WrapLogger(logger.New(logger.WithWriter(&buf)))
+	l.WithFields(map[string]any{"k": "v"}).WithContext(NewContext(context.Background(), "abc")).Info("hello")
+	if !strings.Contains(buf.String(), `"request_id":"abc"`) {
+		t.Fatalf("expected request id in log: %s", buf.String())
+	}
+	if WrapLogger(l) != l {
+		t.Fatal("expected the wrapped logger not wrapped again")
+	}
+}
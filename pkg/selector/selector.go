@@ -0,0 +1,66 @@
+// Package selector carries the node filters of a single call in the context,
+// the client transports apply them with the client filters when selecting the node
+// and report the selected node to the callbacks in the context.
+package selector
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+type filterKey struct{}
+
+// NewFilterContext returns a new context with the node filters appended.
+func NewFilterContext(ctx context.Context, filters ...selector.NodeFilter) context.Context {
+	if len(filters) == 0 {
+		return ctx
+	}
+	prev := FiltersFromContext(ctx)
+	out := make([]selector.NodeFilter, 0, len(prev)+len(filters))
+	out = append(out, prev...)
+	out = append(out, filters...)
+	return context.WithValue(ctx, filterKey{}, out)
+}
+
+// FiltersFromContext returns the node filters in ctx, if any.
+func FiltersFromContext(ctx context.Context) []selector.NodeFilter {
+	filters, _ := ctx.Value(filterKey{}).([]selector.NodeFilter)
+	return filters
+}
+
+// Filters returns the client filters with the filters in ctx appended.
+func Filters(ctx context.Context, filters []selector.NodeFilter) []selector.NodeFilter {
+	fs := FiltersFromContext(ctx)
+	if len(fs) == 0 {
+		return filters
+	}
+	out := make([]selector.NodeFilter, 0, len(filters)+len(fs))
+	out = append(out, filters...)
+	return append(out, fs...)
+}
+
+type selectedKey struct{}
+
+// NewSelectedContext returns a new context with the callback called by the transports with the selected node,
+// the callbacks already in ctx are called first. The transports fail the call with the error of a callback.
+func NewSelectedContext(ctx context.Context, fn func(node selector.Node) error) context.Context {
+	if prev, ok := ctx.Value(selectedKey{}).(func(selector.Node) error); ok {
+		next := fn
+		fn = func(node selector.Node) error {
+			if err := prev(node); err != nil {
+				return err
+			}
+			return next(node)
+		}
+	}
+	return context.WithValue(ctx, selectedKey{}, fn)
+}
+
+// Selected calls the callbacks in ctx with the selected node, if any.
+func Selected(ctx context.Context, node selector.Node) error {
+	if fn, ok := ctx.Value(selectedKey{}).(func(selector.Node) error); ok {
+		return fn(node)
+	}
+	return nil
+}
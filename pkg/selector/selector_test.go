@@ -0,0 +1,70 @@
+package selector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/selector"
+)
+
+func TestFilters(t *testing.T) {
+	var called []string
+	filter := func(name string) selector.NodeFilter {
+		return func(_ context.Context, nodes []selector.Node) []selector.Node {
+			called = append(called, name)
+			return nodes
+		}
+	}
+
+	ctx := context.Background()
+	if fs := Filters(ctx, nil); len(fs) != 0 {
+		t.Fatalf("expect no filters, got %d", len(fs))
+	}
+
+	ctx = NewFilterContext(ctx, filter("a"))
+	ctx = NewFilterContext(ctx, filter("b"))
+	fs := Filters(ctx, []selector.NodeFilter{filter("client")})
+	for _, f := range fs {
+		f(ctx, nil)
+	}
+	if len(called) != 3 || called[0] != "client" || called[1] != "a" || called[2] != "b" {
+		t.Fatalf("unexpected filters: %v", called)
+	}
+}
+
+func TestSelected(t *testing.T) {
+	// no callback
+	if err := Selected(context.Background(), nil); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+
+	var selected []selector.Node
+	ctx := NewSelectedContext(context.Background(), func(node selector.Node) error {
+		selected = append(selected, node)
+		return nil
+	})
+	node := selector.NewNode("grpc", "127.0.0.1:9000", nil)
+	if err := Selected(ctx, node); err != nil {
+		t.Fatalf("expect no error, got %v", err)
+	}
+	if len(selected) != 1 || selected[0] != node {
+		t.Fatalf("expect %v, got %v", node, selected)
+	}
+
+	// the callbacks are called in order until an error
+	rejected := errors.New("rejected")
+	ctx = NewSelectedContext(ctx, func(selector.Node) error {
+		return rejected
+	})
+	ctx = NewSelectedContext(ctx, func(node selector.Node) error {
+		t.Fatal("unexpected call")
+		return nil
+	})
+	if err := Selected(ctx, node); err != rejected {
+		t.Fatalf("expect %v, got %v", rejected, err)
+	}
+	if len(selected) != 2 {
+		t.Fatalf("expect 2 calls, got %d", len(selected))
+	}
+}
@@ -0,0 +1,374 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-kratos/kratos/v2/registry"
+	"github.com/nextmicro/logger"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	fileLockTimeout = 5 * time.Second
+	fileLockStale   = 30 * time.Second
+)
+
+// fileInstance is the service instance stored in the file.
+type fileInstance struct {
+	ID        string            `json:"id" yaml:"id"`
+	Name      string            `json:"name" yaml:"name"`
+	Version   string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Metadata  map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	Endpoints []string          `json:"endpoints" yaml:"endpoints"`
+}
+
+// fileContent is the content of the registry file.
+type fileContent struct {
+	Services []fileInstance `json:"services" yaml:"services"`
+}
+
+type fileRegistry struct {
+	path string
+
+	mu        sync.RWMutex
+	instances map[string][]*registry.ServiceInstance
+	watchers  map[*fileWatcher]struct{}
+
+	fsWatcher *fsnotify.Watcher
+	exit      chan struct{}
+}
+
+// NewFile returns a registry backed by a YAML or JSON file of service instances,
+// the format is selected by the file extension (.json, otherwise YAML):
+//
+//	services:
+//	  - id: 1
+//	    name: greeter
+//	    version: v1.0.0
+//	    endpoints:
+//	      - grpc://127.0.0.1:9000
+//
+// The file is watched and the changes are pushed to the watchers,
+// Register and Deregister rewrite the file atomically.
+func NewFile(path string) (Registry, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// watch the directory, the file is replaced by rename
+	if err = fsWatcher.Add(filepath.Dir(path)); err != nil {
+		_ = fsWatcher.Close()
+		return nil, err
+	}
+
+	r := &fileRegistry{
+		path:      path,
+		instances: make(map[string][]*registry.ServiceInstance),
+		watchers:  make(map[*fileWatcher]struct{}),
+		fsWatcher: fsWatcher,
+		exit:      make(chan struct{}),
+	}
+	if err = r.reload(); err != nil {
+		_ = fsWatcher.Close()
+		return nil, err
+	}
+	go r.watch()
+
+	return r, nil
+}
+
+func (r *fileRegistry) watch() {
+	for {
+		select {
+		case <-r.exit:
+			return
+		case event, ok := <-r.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(event.Name) != r.path {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				logger.Errorf("Registry reload file %s error: %v", r.path, err)
+			}
+		case err, ok := <-r.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			logger.Errorf("Registry watch file %s error: %v", r.path, err)
+		}
+	}
+}
+
+// reload reads the file and notifies the watchers of the changed services.
+func (r *fileRegistry) reload() error {
+	content, err := r.read()
+	if err != nil {
+		return err
+	}
+
+	instances := make(map[string][]*registry.ServiceInstance)
+	for _, in := range content.Services {
+		instances[in.Name] = append(instances[in.Name], &registry.ServiceInstance{
+			ID:        in.ID,
+			Name:      in.Name,
+			Version:   in.Version,
+			Metadata:  in.Metadata,
+			Endpoints: in.Endpoints,
+		})
+	}
+
+	r.mu.Lock()
+	old := r.instances
+	r.instances = instances
+	watchers := make([]*fileWatcher, 0, len(r.watchers))
+	for w := range r.watchers {
+		watchers = append(watchers, w)
+	}
+	r.mu.Unlock()
+
+	for _, w := range watchers {
+		if !reflect.DeepEqual(old[w.service], instances[w.service]) {
+			w.notify()
+		}
+	}
+	return nil
+}
+
+func (r *fileRegistry) read() (*fileContent, error) {
+	content := &fileContent{}
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return content, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return content, nil
+	}
+
+	if r.isJSON() {
+		err = json.Unmarshal(data, content)
+	} else {
+		err = yaml.Unmarshal(data, content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse registry file %s error: %w", r.path, err)
+	}
+	return content, nil
+}
+
+func (r *fileRegistry) isJSON() bool {
+	return strings.EqualFold(filepath.Ext(r.path), ".json")
+}
+
+// update rewrites the file atomically with the instances modified by `fn`.
+func (r *fileRegistry) update(fn func(content *fileContent)) error {
+	unlock, err := r.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	content, err := r.read()
+	if err != nil {
+		return err
+	}
+	fn(content)
+
+	var data []byte
+	if r.isJSON() {
+		data, err = json.MarshalIndent(content, "", "  ")
+	} else {
+		data, err = yaml.Marshal(content)
+	}
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), "."+filepath.Base(r.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), r.path); err != nil {
+		return err
+	}
+	return r.reload()
+}
+
+// lock creates the lock file to serialize the rewrites between processes,
+// the lock file older than fileLockStale is removed.
+func (r *fileRegistry) lock() (func(), error) {
+	name := r.path + ".lock"
+	deadline := time.Now().Add(fileLockTimeout)
+	for {
+		f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(name) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if fi, err := os.Stat(name); err == nil && time.Since(fi.ModTime()) > fileLockStale {
+			_ = os.Remove(name)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("lock registry file %s timeout", r.path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (r *fileRegistry) Register(ctx context.Context, service *registry.ServiceInstance) error {
+	err := r.update(func(content *fileContent) {
+		content.Services = removeInstance(content.Services, service)
+		content.Services = append(content.Services, fileInstance{
+			ID:        service.ID,
+			Name:      service.Name,
+			Version:   service.Version,
+			Metadata:  service.Metadata,
+			Endpoints: service.Endpoints,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Registry added new service: %s, version: %s, file: %s", service.Name, service.Version, r.path)
+	return nil
+}
+
+func (r *fileRegistry) Deregister(ctx context.Context, service *registry.ServiceInstance) error {
+	err := r.update(func(content *fileContent) {
+		content.Services = removeInstance(content.Services, service)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Registry removed node from service: %s, version: %s, file: %s", service.Name, service.Version, r.path)
+	return nil
+}
+
+func removeInstance(instances []fileInstance, service *registry.ServiceInstance) []fileInstance {
+	out := instances[:0]
+	for _, in := range instances {
+		if in.Name == service.Name && in.ID == service.ID {
+			continue
+		}
+		out = append(out, in)
+	}
+	return out
+}
+
+func (r *fileRegistry) GetService(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]*registry.ServiceInstance(nil), r.instances[serviceName]...), nil
+}
+
+func (r *fileRegistry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
+	w := &fileWatcher{
+		registry: r,
+		service:  serviceName,
+		event:    make(chan struct{}, 1),
+		exit:     make(chan struct{}),
+	}
+	// the current instances are returned by the first Next
+	w.notify()
+
+	r.mu.Lock()
+	r.watchers[w] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = w.Stop()
+		case <-w.exit:
+		}
+	}()
+	return w, nil
+}
+
+// Close stops watching the file.
+func (r *fileRegistry) Close() error {
+	select {
+	case <-r.exit:
+		return nil
+	default:
+		close(r.exit)
+	}
+	return r.fsWatcher.Close()
+}
+
+type fileWatcher struct {
+	registry *fileRegistry
+	service  string
+	event    chan struct{}
+	exit     chan struct{}
+	once     sync.Once
+}
+
+func (w *fileWatcher) notify() {
+	select {
+	case w.event <- struct{}{}:
+	default:
+	}
+}
+
+func (w *fileWatcher) Next() ([]*registry.ServiceInstance, error) {
+	select {
+	case <-w.exit:
+		return nil, ErrWatcherStopped
+	case <-w.event:
+		return w.registry.GetService(context.Background(), w.service)
+	}
+}
+
+func (w *fileWatcher) Stop() error {
+	w.once.Do(func() {
+		close(w.exit)
+		w.registry.mu.Lock()
+		delete(w.registry.watchers, w)
+		w.registry.mu.Unlock()
+	})
+	return nil
+}
@@ -0,0 +1,117 @@
+package registry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/registry"
+)
+
+func nextWithTimeout(t *testing.T, w registry.Watcher) []*registry.ServiceInstance {
+	t.Helper()
+	type result struct {
+		services []*registry.ServiceInstance
+		err      error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		services, err := w.Next()
+		ch <- result{services, err}
+	}()
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			t.Fatal(r.err)
+		}
+		return r.services
+	case <-time.After(3 * time.Second):
+		t.Fatal("watcher timeout")
+	}
+	return nil
+}
+
+func TestFileRegistry(t *testing.T) {
+	for _, name := range []string{"registry.yaml", "registry.json"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			r, err := NewFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.(*fileRegistry).Close()
+
+			w, err := r.Watch(context.Background(), "greeter")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Stop()
+			if services := nextWithTimeout(t, w); len(services) != 0 {
+				t.Fatalf("expect no instances, got %d", len(services))
+			}
+
+			si := &registry.ServiceInstance{
+				ID:        "1",
+				Name:      "greeter",
+				Version:   "v1.0.0",
+				Endpoints: []string{"grpc://127.0.0.1:9000"},
+			}
+			if err = r.Register(context.Background(), si); err != nil {
+				t.Fatal(err)
+			}
+			services := nextWithTimeout(t, w)
+			if len(services) != 1 || services[0].ID != "1" || services[0].Endpoints[0] != "grpc://127.0.0.1:9000" {
+				t.Fatalf("unexpected instances: %+v", services)
+			}
+
+			// another process registers through a second registry on the same file
+			other, err := NewFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer other.(*fileRegistry).Close()
+			if err = other.Register(context.Background(), &registry.ServiceInstance{
+				ID: "2", Name: "greeter", Endpoints: []string{"grpc://127.0.0.1:9001"},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if services = nextWithTimeout(t, w); len(services) != 2 {
+				t.Fatalf("expect 2 instances, got %+v", services)
+			}
+
+			if err = r.Deregister(context.Background(), si); err != nil {
+				t.Fatal(err)
+			}
+			if services = nextWithTimeout(t, w); len(services) != 1 || services[0].ID != "2" {
+				t.Fatalf("unexpected instances: %+v", services)
+			}
+		})
+	}
+}
+
+func TestFileRegistryEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	r, err := NewFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.(*fileRegistry).Close()
+
+	w, err := r.Watch(context.Background(), "greeter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	nextWithTimeout(t, w)
+
+	content := "services:\n  - id: a\n    name: greeter\n    endpoints:\n      - http://127.0.0.1:8000\n"
+	if err = os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	services := nextWithTimeout(t, w)
+	if len(services) != 1 || services[0].ID != "a" {
+		t.Fatalf("unexpected instances: %+v", services)
+	}
+}
@@ -2,20 +2,16 @@ package registry
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/go-kratos/kratos/v2/registry"
-	"github.com/google/uuid"
 	"github.com/nextmicro/logger"
 )
 
-var (
-	sendEventTime = 10 * time.Millisecond
-	ttlPruneTime  = time.Second
-)
+var ttlPruneTime = time.Second
 
 type record struct {
 	*registry.ServiceInstance
@@ -25,8 +21,9 @@ type record struct {
 
 type memRegistry struct {
 	sync.RWMutex
-	records  map[string]*record
-	watchers map[string]*memoryWatcher
+	// service name -> instance id -> record
+	records  map[string]map[string]*record
+	watchers map[*memoryWatcher]struct{}
 }
 
 // Result is returned by a call to Next on
@@ -38,7 +35,8 @@ type Result struct {
 
 func NewMemory() Registry {
 	reg := &memRegistry{
-		records: make(map[string]*record),
+		records:  make(map[string]map[string]*record),
+		watchers: make(map[*memoryWatcher]struct{}),
 	}
 	go reg.ttlPrune()
 
@@ -49,14 +47,25 @@ func (r *memRegistry) ttlPrune() {
 	prune := time.Tick(ttlPruneTime)
 
 	for range prune {
+		var changed []string
 		r.Lock()
-		for name, record := range r.records {
-			if record.TTL != 0 && time.Since(record.LastSeen) > record.TTL {
-				logger.Infof("Registry TTL expired for node %s of service %s", record.ID, name)
+		for name, records := range r.records {
+			for id, record := range records {
+				if record.TTL != 0 && time.Since(record.LastSeen) > record.TTL {
+					logger.Infof("Registry TTL expired for node %s of service %s", id, name)
+					delete(records, id)
+					changed = append(changed, name)
+				}
+			}
+			if len(records) == 0 {
 				delete(r.records, name)
 			}
 		}
 		r.Unlock()
+
+		for _, name := range changed {
+			r.sendEvent(name)
+		}
 	}
 }
 
@@ -67,116 +76,146 @@ func serviceToRecord(s *registry.ServiceInstance, ttl time.Duration) *record {
 	}
 
 	return &record{
-		ServiceInstance: s,
-		TTL:             ttl,
-		LastSeen:        time.Now(),
+		ServiceInstance: &registry.ServiceInstance{
+			ID:        s.ID,
+			Name:      s.Name,
+			Version:   s.Version,
+			Metadata:  metadata,
+			Endpoints: append([]string(nil), s.Endpoints...),
+		},
+		TTL:      ttl,
+		LastSeen: time.Now(),
 	}
 }
 
 func (r *memRegistry) Register(ctx context.Context, service *registry.ServiceInstance) error {
 	r.Lock()
-	defer r.Unlock()
+	records, ok := r.records[service.Name]
+	if !ok {
+		records = make(map[string]*record)
+		r.records[service.Name] = records
+	}
+	records[service.ID] = serviceToRecord(service, 0)
+	r.Unlock()
 
-	record := serviceToRecord(service, 0)
-	r.records[service.Name] = record
-	go r.sendEvent(&Result{Action: "update", Service: service})
+	r.sendEvent(service.Name)
 	logger.Infof("Registry added new service: %s, version: %s", service.Name, service.Version)
 
 	return nil
 }
 
-func (r *memRegistry) sendEvent(result *Result) {
+// sendEvent notifies the watchers of the service.
+func (r *memRegistry) sendEvent(name string) {
 	r.RLock()
-	watchers := make([]*memoryWatcher, 0, len(r.watchers))
-	for _, w := range r.watchers {
-		watchers = append(watchers, w)
-	}
-	r.RUnlock()
+	defer r.RUnlock()
 
-	for _, w := range watchers {
-		select {
-		case <-w.exit:
-			r.Lock()
-			delete(r.watchers, w.id)
-			r.Unlock()
-		default:
-			select {
-			case w.res <- result:
-			case <-time.After(sendEventTime):
-			}
+	for w := range r.watchers {
+		if w.service == "" || w.service == name {
+			w.notify()
 		}
 	}
 }
 
 func (r *memRegistry) Deregister(ctx context.Context, service *registry.ServiceInstance) error {
 	r.Lock()
-	defer r.Unlock()
+	records, ok := r.records[service.Name]
+	if ok {
+		if _, ok = records[service.ID]; ok {
+			delete(records, service.ID)
+		}
+		if len(records) == 0 {
+			delete(r.records, service.Name)
+		}
+	}
+	r.Unlock()
 
-	if _, ok := r.records[service.Name]; ok {
+	if ok {
 		logger.Infof("Registry removed node from service: %s, version: %s", service.Name, service.Version)
-		delete(r.records, service.Name)
+		r.sendEvent(service.Name)
 	}
 
-	go r.sendEvent(&Result{Action: "delete", Service: service})
-
 	return nil
 }
 
 func (r *memRegistry) GetService(ctx context.Context, serviceName string) ([]*registry.ServiceInstance, error) {
-	r.Lock()
-	defer r.Unlock()
-
-	record, ok := r.records[serviceName]
-	if !ok {
+	services := r.services(serviceName)
+	if len(services) == 0 {
 		return nil, fmt.Errorf("service %s not found in registry", serviceName)
 	}
 
-	return []*registry.ServiceInstance{record.ServiceInstance}, nil
+	return services, nil
+}
+
+// services returns the instances of the service sorted by id.
+func (r *memRegistry) services(serviceName string) []*registry.ServiceInstance {
+	r.RLock()
+	defer r.RUnlock()
+
+	records := r.records[serviceName]
+	services := make([]*registry.ServiceInstance, 0, len(records))
+	for _, record := range records {
+		services = append(services, record.ServiceInstance)
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].ID < services[j].ID
+	})
+	return services
 }
 
+// Watch returns the watcher of the service, the first Next returns the current instances.
 func (r *memRegistry) Watch(ctx context.Context, serviceName string) (registry.Watcher, error) {
 	w := &memoryWatcher{
-		exit:    make(chan bool),
-		res:     make(chan *Result),
-		id:      uuid.New().String(),
-		service: serviceName,
+		registry: r,
+		service:  serviceName,
+		event:    make(chan struct{}, 1),
+		exit:     make(chan struct{}),
 	}
+	w.notify()
 
 	r.Lock()
-	r.watchers[w.id] = w
+	r.watchers[w] = struct{}{}
 	r.Unlock()
 
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = w.Stop()
+		case <-w.exit:
+		}
+	}()
 	return w, nil
 }
 
 type memoryWatcher struct {
-	id      string
-	service string
-	res     chan *Result
-	exit    chan bool
+	registry *memRegistry
+	service  string
+	event    chan struct{}
+	exit     chan struct{}
+	once     sync.Once
 }
 
-func (m *memoryWatcher) Next() ([]*registry.ServiceInstance, error) {
-	for {
-		select {
-		case r := <-m.res:
-			if len(m.service) > 0 && m.service != r.Service.Name {
-				continue
-			}
-			return []*registry.ServiceInstance{r.Service}, nil
-		case <-m.exit:
-			return nil, errors.New("watcher stopped")
-		}
+func (m *memoryWatcher) notify() {
+	select {
+	case m.event <- struct{}{}:
+	default:
 	}
 }
 
-func (m *memoryWatcher) Stop() error {
+func (m *memoryWatcher) Next() ([]*registry.ServiceInstance, error) {
 	select {
 	case <-m.exit:
-		return nil
-	default:
-		close(m.exit)
+		return nil, ErrWatcherStopped
+	case <-m.event:
+		return m.registry.services(m.service), nil
 	}
+}
 
+func (m *memoryWatcher) Stop() error {
+	m.once.Do(func() {
+		close(m.exit)
+		m.registry.Lock()
+		delete(m.registry.watchers, m)
+		m.registry.Unlock()
+	})
 	return nil
 }
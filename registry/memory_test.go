@@ -0,0 +1,55 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/registry"
+)
+
+func TestMemoryRegistry(t *testing.T) {
+	r := NewMemory()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w, err := r.Watch(ctx, "greeter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if services := nextWithTimeout(t, w); len(services) != 0 {
+		t.Fatalf("expect no instances, got %d", len(services))
+	}
+
+	a := &registry.ServiceInstance{ID: "a", Name: "greeter", Endpoints: []string{"grpc://127.0.0.1:9000"}}
+	b := &registry.ServiceInstance{ID: "b", Name: "greeter", Endpoints: []string{"grpc://127.0.0.1:9001"}}
+	for _, si := range []*registry.ServiceInstance{a, b} {
+		if err = r.Register(context.Background(), si); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the other services are not watched
+	if err = r.Register(context.Background(), &registry.ServiceInstance{ID: "c", Name: "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	services := nextWithTimeout(t, w)
+	if len(services) != 2 || services[0].ID != "a" || services[1].ID != "b" {
+		t.Fatalf("unexpected instances: %+v", services)
+	}
+	if services, err = r.GetService(context.Background(), "greeter"); err != nil || len(services) != 2 {
+		t.Fatalf("unexpected instances: %+v, error: %v", services, err)
+	}
+
+	if err = r.Deregister(context.Background(), a); err != nil {
+		t.Fatal(err)
+	}
+	if services = nextWithTimeout(t, w); len(services) != 1 || services[0].ID != "b" {
+		t.Fatalf("unexpected instances: %+v", services)
+	}
+
+	// the watcher is stopped with the context
+	cancel()
+	if _, err = w.Next(); err != ErrWatcherStopped {
+		t.Fatalf("expect %v, got %v", ErrWatcherStopped, err)
+	}
+}
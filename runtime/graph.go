@@ -0,0 +1,70 @@
+package runtime
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nextmicro/next/runtime/loader"
+)
+
+// dependencies returns the loader names `load` depends on.
+func dependencies(load loader.Loader) []string {
+	if d, ok := load.(loader.Dependent); ok {
+		return d.Dependencies()
+	}
+	return nil
+}
+
+// sortLoaders sorts the loaders in topological order of the dependencies,
+// the slice order is kept for the independent loaders.
+func sortLoaders(loaders []loader.Loader) ([]loader.Loader, error) {
+	index := make(map[string]int, len(loaders))
+	for i, load := range loaders {
+		if _, ok := index[load.String()]; ok {
+			return nil, fmt.Errorf("duplicate loader: %s", load.String())
+		}
+		index[load.String()] = i
+	}
+
+	indegree := make([]int, len(loaders))
+	dependents := make([][]int, len(loaders))
+	for i, load := range loaders {
+		for _, dep := range dependencies(load) {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("%s: depends on unknown loader %s", load.String(), dep)
+			}
+			indegree[i]++
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	sorted := make([]loader.Loader, 0, len(loaders))
+	visited := make([]bool, len(loaders))
+	for len(sorted) < len(loaders) {
+		next := -1
+		for i := range loaders {
+			if !visited[i] && indegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			var cycle []string
+			for i, load := range loaders {
+				if !visited[i] {
+					cycle = append(cycle, load.String())
+				}
+			}
+			return nil, fmt.Errorf("dependency cycle between loaders: %s", strings.Join(cycle, ", "))
+		}
+
+		visited[next] = true
+		sorted = append(sorted, loaders[next])
+		for _, i := range dependents[next] {
+			indegree[i]--
+		}
+	}
+
+	return sorted, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/nextmicro/next/adapter/broker/kafka"
 	"github.com/nextmicro/next/adapter/broker/wrapper/logging"
 	"github.com/nextmicro/next/adapter/broker/wrapper/metrics"
+	"github.com/nextmicro/next/adapter/broker/wrapper/requestid"
 	"github.com/nextmicro/next/broker"
 	conf "github.com/nextmicro/next/config"
 	"github.com/nextmicro/next/runtime/loader"
@@ -63,6 +64,7 @@ func (loader *wrapper) Init(opts ...loader.Option) error {
 				metrics.WithAddr(strings.Join(brokerCfg.GetAddrs(), ",")),
 				metrics.WithQueue(queueName),
 			),
+			requestid.NewWrapper(),
 		),
 	)
 
@@ -90,6 +92,11 @@ func (loader *wrapper) Start(ctx context.Context) (err error) {
 
 // Stop the broker
 func (loader *wrapper) Stop(ctx context.Context) (err error) {
+	// stop consuming and wait for the in-flight handlers
+	if err = broker.Drain(ctx, broker.DefaultBroker); err != nil {
+		logger.Warnf("Broker [%s] drain error: %v", broker.DefaultBroker.String(), err)
+	}
+
 	// disconnect broker
 	if err = broker.DefaultBroker.Disconnect(); err != nil {
 		logger.Errorf("Broker [%s] disconnect error: %v", broker.DefaultBroker.String(), err)
@@ -101,6 +108,16 @@ func (loader *wrapper) Stop(ctx context.Context) (err error) {
 	return
 }
 
+// Health checks the health of the broker
+func (loader *wrapper) Health(ctx context.Context) error {
+	return broker.Health(ctx, broker.DefaultBroker)
+}
+
+// Dependencies returns the loaders the broker depends on
+func (loader *wrapper) Dependencies() []string {
+	return []string{"Logger", "otel"}
+}
+
 // String returns the name of broker
 func (loader *wrapper) String() string {
 	return "Broker"
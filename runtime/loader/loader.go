@@ -19,6 +19,20 @@ type Loader interface {
 	String() string
 }
 
+// Dependent is implemented by the loaders which depend on other loaders,
+// the runtime starts a loader after its dependencies and stops it before them.
+type Dependent interface {
+	// Dependencies returns the names of the loaders depended on, see Loader.String
+	Dependencies() []string
+}
+
+// HealthChecker is implemented by the loaders which can report their health,
+// the runtime registers them into the health aggregator after start.
+type HealthChecker interface {
+	// Health returns nil if the loader is healthy
+	Health(ctx context.Context) error
+}
+
 type BaseLoader struct {
 }
 
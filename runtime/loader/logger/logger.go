@@ -13,6 +13,8 @@ import (
 	config "github.com/nextmicro/next/api/config/v1"
 	conf "github.com/nextmicro/next/config"
 	"github.com/nextmicro/next/pkg/env"
+	"github.com/nextmicro/next/pkg/redact"
+	"github.com/nextmicro/next/pkg/requestid"
 	"github.com/nextmicro/next/runtime/loader"
 )
 
@@ -66,9 +68,9 @@ func (loader *logger) Init(opts ...loader.Option) error {
 		logCfg.Path = fmt.Sprintf(loggerPath, conf.ApplicationConfig().GetName())
 	}
 
-	log.DefaultLogger = log.New(options(logCfg)...) // adapter logger
-	kratos.New(log.DefaultLogger).SetLogger()       // adapter kratos logger
-	nacos.NewNacos(log.DefaultLogger).SetLogger()   // adapter nacos logger
+	log.DefaultLogger = requestid.WrapLogger(redact.WrapLogger(log.New(options(logCfg)...))) // adapter logger
+	kratos.New(log.DefaultLogger).SetLogger()                                                // adapter kratos logger
+	nacos.NewNacos(log.DefaultLogger).SetLogger()                                            // adapter nacos logger
 
 	loader.cfg = logCfg
 	loader.opt.Initialized = true
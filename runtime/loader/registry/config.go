@@ -0,0 +1,138 @@
+package registry
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	config "github.com/nextmicro/next/api/config/v1"
+)
+
+// default ports of the registries
+var defaultPorts = map[string]string{
+	"consul": "8500",
+	"etcd":   "2379",
+	"nacos":  "8848",
+}
+
+// validate checks the registry config, the errors name the bad field.
+func validate(c *config.Registry) error {
+	switch c.GetName() {
+	case "", "memory", "file", "consul", "etcd", "nacos":
+	default:
+		return fmt.Errorf("registry.name: unsupported registry %q", c.GetName())
+	}
+
+	if _, ok := defaultPorts[c.GetName()]; ok {
+		if _, err := parseAddrs(c.GetName(), c.GetAddrs()); err != nil {
+			return err
+		}
+	}
+	if c.GetTimeout().AsDuration() < 0 {
+		return fmt.Errorf("registry.timeout: must not be negative")
+	}
+	if ttl := c.GetTtl(); ttl != nil && ttl.AsDuration() < time.Second {
+		return fmt.Errorf("registry.ttl: must be at least 1s, got %s", ttl.AsDuration())
+	}
+	if interval := c.GetHealthCheckInterval(); interval != nil && interval.AsDuration() < time.Second {
+		return fmt.Errorf("registry.health_check_interval: must be at least 1s, got %s", interval.AsDuration())
+	}
+	if c.GetDeregisterCriticalAfter().AsDuration() < 0 {
+		return fmt.Errorf("registry.deregister_critical_after: must not be negative")
+	}
+	if c.GetWeight() < 0 {
+		return fmt.Errorf("registry.weight: must not be negative, got %v", c.GetWeight())
+	}
+	if c.GetPassword() != "" && c.GetUsername() == "" {
+		return fmt.Errorf("registry.username: required with registry.password")
+	}
+
+	if t := c.GetTls(); t.GetEnable() {
+		if t.GetCertFile() != "" && t.GetKeyFile() == "" {
+			return fmt.Errorf("registry.tls.key_file: required with registry.tls.cert_file")
+		}
+		if t.GetKeyFile() != "" && t.GetCertFile() == "" {
+			return fmt.Errorf("registry.tls.cert_file: required with registry.tls.key_file")
+		}
+		for _, f := range [][2]string{
+			{"registry.tls.ca_file", t.GetCaFile()},
+			{"registry.tls.cert_file", t.GetCertFile()},
+			{"registry.tls.key_file", t.GetKeyFile()},
+		} {
+			if f[1] == "" {
+				continue
+			}
+			if _, err := os.Stat(f[1]); err != nil {
+				return fmt.Errorf("%s: %v", f[0], err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseAddrs splits the comma separated addresses, the default port of the registry is added if missing.
+func parseAddrs(name, addrs string) ([]string, error) {
+	var out []string
+	for _, address := range strings.Split(addrs, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		// the scheme is not part of the address
+		if i := strings.Index(address, "://"); i >= 0 {
+			address = address[i+3:]
+		}
+
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			if defaultPorts[name] == "" || strings.Count(address, ":") > 0 {
+				return nil, fmt.Errorf("registry.addrs: invalid address %q: %v", address, err)
+			}
+			host, port = address, defaultPorts[name]
+		}
+		if host == "" {
+			return nil, fmt.Errorf("registry.addrs: invalid address %q: missing host", address)
+		}
+		if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+			return nil, fmt.Errorf("registry.addrs: invalid address %q: bad port %q", address, port)
+		}
+		out = append(out, net.JoinHostPort(host, port))
+	}
+	return out, nil
+}
+
+// buildTLS builds the tls config, nil if tls is not enabled.
+func buildTLS(c *config.TLS) (*tls.Config, error) {
+	if !c.GetEnable() {
+		return nil, nil
+	}
+
+	conf := &tls.Config{
+		ServerName:         c.GetServerName(),
+		InsecureSkipVerify: c.GetInsecureSkipVerify(), // nolint:gosec
+	}
+	if c.GetCaFile() != "" {
+		ca, err := os.ReadFile(c.GetCaFile())
+		if err != nil {
+			return nil, fmt.Errorf("registry.tls.ca_file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("registry.tls.ca_file: no certificate found in %s", c.GetCaFile())
+		}
+		conf.RootCAs = pool
+	}
+	if c.GetCertFile() != "" {
+		cert, err := tls.LoadX509KeyPair(c.GetCertFile(), c.GetKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("registry.tls.cert_file: %v", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+	return conf, nil
+}
@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/nextmicro/next/api/config/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestParseAddrs(t *testing.T) {
+	tests := []struct {
+		name   string
+		addrs  string
+		expect []string
+		err    string
+	}{
+		{"consul", "127.0.0.1", []string{"127.0.0.1:8500"}, ""},
+		{"etcd", "10.0.0.1, 10.0.0.2:2380,http://10.0.0.3", []string{"10.0.0.1:2379", "10.0.0.2:2380", "10.0.0.3:2379"}, ""},
+		{"nacos", "nacos.local", []string{"nacos.local:8848"}, ""},
+		{"etcd", "10.0.0.1:abc", nil, "registry.addrs: invalid address"},
+		{"etcd", ":2379", nil, "registry.addrs: invalid address"},
+	}
+	for _, test := range tests {
+		addrs, err := parseAddrs(test.name, test.addrs)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s %q: expect error %q, got %v", test.name, test.addrs, test.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: unexpected error %v", test.name, test.addrs, err)
+			continue
+		}
+		if !reflect.DeepEqual(addrs, test.expect) {
+			t.Errorf("%s %q: expect %v, got %v", test.name, test.addrs, test.expect, addrs)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		cfg *config.Registry
+		err string
+	}{
+		{&config.Registry{Name: "consul", Addrs: "127.0.0.1:8500", Ttl: durationpb.New(10 * time.Second)}, ""},
+		{&config.Registry{Name: "zookeeper"}, "registry.name"},
+		{&config.Registry{Name: "etcd", Ttl: durationpb.New(time.Millisecond)}, "registry.ttl"},
+		{&config.Registry{Name: "consul", HealthCheckInterval: durationpb.New(0)}, "registry.health_check_interval"},
+		{&config.Registry{Name: "etcd", Password: "secret"}, "registry.username"},
+		{&config.Registry{Name: "nacos", Weight: -1}, "registry.weight"},
+		{&config.Registry{Name: "etcd", Tls: &config.TLS{Enable: true, CertFile: "client.pem"}}, "registry.tls.key_file"},
+		{&config.Registry{Name: "etcd", Tls: &config.TLS{Enable: true, CaFile: "/not/exist/ca.pem"}}, "registry.tls.ca_file"},
+	}
+	for _, test := range tests {
+		err := validate(test.cfg)
+		if test.err == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", test.cfg, err)
+			}
+			continue
+		}
+		if err == nil || !strings.HasPrefix(err.Error(), test.err) {
+			t.Errorf("%v: expect error %q, got %v", test.cfg, test.err, err)
+		}
+	}
+}
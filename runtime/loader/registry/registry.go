@@ -2,27 +2,35 @@ package registry
 
 import (
 	"context"
-
+	"fmt"
+	"io"
 	"net"
-	"strings"
+	"path/filepath"
+	"time"
 
 	"github.com/go-kratos/kratos/contrib/registry/consul/v2"
 	etcd "github.com/go-kratos/kratos/contrib/registry/etcd/v2"
 	"github.com/hashicorp/consul/api"
+	"github.com/nacos-group/nacos-sdk-go/v2/clients"
 	"github.com/nextmicro/logger"
+	"github.com/nextmicro/next/adapter/registry/nacos"
 	config "github.com/nextmicro/next/api/config/v1"
 	conf "github.com/nextmicro/next/config"
+	"github.com/nextmicro/next/pkg/env"
 	"github.com/nextmicro/next/registry"
 	"github.com/nextmicro/next/runtime/loader"
 	"github.com/pkg/errors"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/protobuf/proto"
 )
 
 type wrapper struct {
 	*loader.BaseLoader
 
-	opt loader.Options
-	cfg *config.Registry
+	opt    loader.Options
+	cfg    *config.Registry
+	health func(ctx context.Context) error
+	close  func()
 }
 
 func New(opts ...loader.Option) loader.Loader {
@@ -33,6 +41,10 @@ func New(opts ...loader.Option) loader.Loader {
 	}
 }
 
+func (loader *wrapper) Initialized() bool {
+	return loader.opt.Initialized
+}
+
 // Init options
 func (loader *wrapper) Init(opts ...loader.Option) error {
 	cfg := conf.ApplicationConfig()
@@ -43,55 +55,81 @@ func (loader *wrapper) Init(opts ...loader.Option) error {
 		}
 	}
 
-	// check if there are any addrs
-	var addrs []string
-
-	// iterate the options addresses
-	for _, address := range strings.Split(cfg.GetRegistry().GetAddrs(), ",") {
-		// check we have a port
-		addr, port, err := net.SplitHostPort(address)
-		var ae *net.AddrError
-		if errors.As(err, &ae) && ae.Err == "missing port in address" {
-			port = "8500"
-			addr = address
-			addrs = append(addrs, net.JoinHostPort(addr, port))
-		} else if err == nil {
-			addrs = append(addrs, net.JoinHostPort(addr, port))
-		}
+	if err := validate(loader.cfg); err != nil {
+		return err
 	}
 
-	switch cfg.GetRegistry().GetName() {
+	switch loader.cfg.GetName() {
 	case "consul":
-		_config := api.DefaultNonPooledConfig()
-		if len(addrs) > 0 {
-			_config.Address = addrs[0]
+		client, err := newConsul(loader.cfg)
+		if err != nil {
+			return errors.WithStack(err)
 		}
 
-		// new consul client
-		client, err := api.NewClient(_config)
+		// new reg with consul client
+		reg := consul.New(client, consulOptions(loader.cfg)...)
+		registry.DefaultRegistry = reg
+		loader.health = func(context.Context) error {
+			_, err := client.Status().Leader()
+			return err
+		}
+	case "etcd":
+		client, err := newEtcd(loader.cfg)
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		// test the client
-		_, err = client.Agent().Host()
+
+		// new reg with etcd client
+		opts := make([]etcd.Option, 0, 2)
+		if ttl := loader.cfg.GetTtl(); ttl != nil {
+			opts = append(opts, etcd.RegisterTTL(ttl.AsDuration()))
+		}
+		if loader.cfg.GetNamespace() != "" {
+			opts = append(opts, etcd.Namespace(loader.cfg.GetNamespace()))
+		}
+		reg := etcd.New(client, opts...)
+		registry.DefaultRegistry = reg
+		loader.health = func(ctx context.Context) error {
+			// healthy while any member answers, the cluster keeps serving with a member down
+			var err error
+			for _, endpoint := range client.Endpoints() {
+				if _, err = client.Status(ctx, endpoint); err == nil {
+					return nil
+				}
+			}
+			return err
+		}
+		loader.close = func() {
+			_ = client.Close()
+		}
+	case "nacos":
+		reg, err := newNacos(loader.cfg, cfg.GetNacos())
 		if err != nil {
 			return errors.WithStack(err)
 		}
-
-		// new reg with consul client
-		reg := consul.New(client)
 		registry.DefaultRegistry = reg
-	case "etcd":
-		// new etcd client
-		client, err := clientv3.New(clientv3.Config{
-			Endpoints: addrs,
-		})
+		loader.health = func(context.Context) error {
+			if !reg.Healthy() {
+				return errors.New("nacos server is not healthy")
+			}
+			return nil
+		}
+		loader.close = reg.Close
+	case "file":
+		path := loader.cfg.GetAddrs()
+		if path == "" {
+			path = filepath.Join(env.WorkDir(), "runtime", "registry.yaml")
+		}
+		reg, err := registry.NewFile(path)
 		if err != nil {
 			return errors.WithStack(err)
 		}
-		// new reg with etcd client
-		reg := etcd.New(client)
 		registry.DefaultRegistry = reg
+		loader.close = func() {
+			if c, ok := reg.(io.Closer); ok {
+				_ = c.Close()
+			}
+		}
 	default:
 		registry.DefaultRegistry = registry.NewMemory()
 	}
@@ -106,6 +144,173 @@ func (loader *wrapper) Start(ctx context.Context) (err error) {
 	return
 }
 
+// Stop the registry
+func (loader *wrapper) Stop(ctx context.Context) error {
+	if loader.close != nil {
+		loader.close()
+	}
+	return nil
+}
+
+// newConsul creates the consul client, the first reachable address is used.
+func newConsul(c *config.Registry) (*api.Client, error) {
+	addrs, err := parseAddrs("consul", c.GetAddrs())
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		addrs = []string{api.DefaultConfig().Address}
+	}
+
+	var lastErr error
+	for _, addr := range addrs {
+		_config := api.DefaultNonPooledConfig()
+		_config.Address = addr
+		_config.Token = c.GetToken()
+		_config.Datacenter = c.GetDatacenter()
+		if c.GetUsername() != "" {
+			_config.HttpAuth = &api.HttpBasicAuth{
+				Username: c.GetUsername(),
+				Password: c.GetPassword(),
+			}
+		}
+		if t := c.GetTls(); t.GetEnable() {
+			_config.Scheme = "https"
+			_config.TLSConfig = api.TLSConfig{
+				Address:            t.GetServerName(),
+				CAFile:             t.GetCaFile(),
+				CertFile:           t.GetCertFile(),
+				KeyFile:            t.GetKeyFile(),
+				InsecureSkipVerify: t.GetInsecureSkipVerify(),
+			}
+		}
+		if timeout := c.GetTimeout().AsDuration(); timeout > 0 {
+			// bounds connecting to the agent, the blocking queries of the watchers wait longer,
+			// the requests are bounded by the registry, see consulOptions
+			_config.Transport.DialContext = (&net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}).DialContext
+			_config.Transport.TLSHandshakeTimeout = timeout
+		}
+
+		// new consul client
+		client, err := api.NewClient(_config)
+		if err != nil {
+			return nil, err
+		}
+		// test the client
+		if _, err = client.Agent().Host(); err != nil {
+			lastErr = fmt.Errorf("consul %s: %w", addr, err)
+			continue
+		}
+		return client, nil
+	}
+	return nil, lastErr
+}
+
+// consulOptions returns the consul registry options,
+// the ttl enables the heartbeat check which is updated every health_check_interval, ttl/2 by default.
+func consulOptions(c *config.Registry) []consul.Option {
+	var opts []consul.Option
+	if timeout := c.GetTimeout().AsDuration(); timeout > 0 {
+		opts = append(opts, consul.WithTimeout(timeout))
+	}
+	interval := c.GetHealthCheckInterval().AsDuration()
+	if ttl := c.GetTtl(); ttl != nil {
+		opts = append(opts, consul.WithHeartbeat(true))
+		if interval == 0 {
+			interval = ttl.AsDuration() / 2
+		}
+	}
+	if interval > 0 {
+		opts = append(opts, consul.WithHealthCheckInterval(int(interval.Seconds())))
+	}
+	if after := c.GetDeregisterCriticalAfter().AsDuration(); after > 0 {
+		opts = append(opts, consul.WithDeregisterCriticalServiceAfter(int(after.Seconds())))
+	}
+	return opts
+}
+
+// newEtcd creates the etcd client with all the addresses.
+func newEtcd(c *config.Registry) (*clientv3.Client, error) {
+	addrs, err := parseAddrs("etcd", c.GetAddrs())
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := buildTLS(c.GetTls())
+	if err != nil {
+		return nil, err
+	}
+
+	return clientv3.New(clientv3.Config{
+		Endpoints:   addrs,
+		DialTimeout: c.GetTimeout().AsDuration(),
+		Username:    c.GetUsername(),
+		Password:    c.GetPassword(),
+		TLS:         tlsConfig,
+	})
+}
+
+// newNacos creates the nacos naming registry, the address and auth settings of `nacos` are reused,
+// `registry.addrs` and `registry.namespace` override them if set.
+func newNacos(c *config.Registry, nc *config.Nacos) (*nacos.Registry, error) {
+	n := &config.Nacos{}
+	if nc != nil {
+		n = proto.Clone(nc).(*config.Nacos)
+	}
+	if c.GetAddrs() != "" {
+		addrs, err := parseAddrs("nacos", c.GetAddrs())
+		if err != nil {
+			return nil, err
+		}
+		n.Address = addrs
+	}
+	if len(n.GetAddress()) == 0 {
+		return nil, errors.New("missing nacos addrs in config file")
+	}
+	if c.GetNamespace() != "" {
+		n.Namespace = c.GetNamespace()
+	}
+	if c.GetTimeout() != nil {
+		n.Timeout = c.GetTimeout()
+	}
+
+	param, err := conf.NacosClientParam(n)
+	if err != nil {
+		return nil, err
+	}
+	client, err := clients.NewNamingClient(param)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := make([]nacos.Option, 0, 4)
+	if c.GetGroup() != "" {
+		opts = append(opts, nacos.WithGroup(c.GetGroup()))
+	}
+	if c.GetCluster() != "" {
+		opts = append(opts, nacos.WithCluster(c.GetCluster()))
+	}
+	if c.GetWeight() > 0 {
+		opts = append(opts, nacos.WithWeight(c.GetWeight()))
+	}
+	if c.Ephemeral != nil {
+		opts = append(opts, nacos.WithEphemeral(c.GetEphemeral()))
+	}
+	return nacos.New(client, opts...), nil
+}
+
+// Health checks the connection to the registry
+func (loader *wrapper) Health(ctx context.Context) error {
+	if loader.health == nil {
+		return nil
+	}
+	return loader.health(ctx)
+}
+
+// Dependencies returns the loaders the registry depends on
+func (loader *wrapper) Dependencies() []string {
+	return []string{"Logger"}
+}
+
 // String returns the name of registry
 func (loader *wrapper) String() string {
 	return "Registry"
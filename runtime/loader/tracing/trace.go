@@ -83,6 +83,10 @@ func (loader *Tracing) Stop(ctx context.Context) error {
 	return nil
 }
 
+func (loader *Tracing) Dependencies() []string {
+	return []string{"Logger"}
+}
+
 func (loader *Tracing) String() string {
 	return "otel"
 }
@@ -1,6 +1,9 @@
 package runtime
 
 import (
+	"time"
+
+	"github.com/nextmicro/next/pkg/health"
 	"github.com/nextmicro/next/runtime/loader"
 	"github.com/nextmicro/next/runtime/loader/broker"
 	"github.com/nextmicro/next/runtime/loader/logger"
@@ -12,7 +15,9 @@ type Option func(o *Options)
 
 // Options configure runtime
 type Options struct {
-	loader []loader.Loader
+	loader      []loader.Loader
+	health      *health.Health
+	stopTimeout time.Duration
 }
 
 // applyOptions configure runtime
@@ -24,6 +29,8 @@ func applyOptions(opts ...Option) Options {
 			registry.New(), // registry loader
 			broker.New(),   // broker loader
 		},
+		health:      health.DefaultHealth,
+		stopTimeout: 5 * time.Second,
 	}
 
 	// apply requested options
@@ -38,3 +45,13 @@ func applyOptions(opts ...Option) Options {
 func Loader(loader ...loader.Loader) Option {
 	return func(o *Options) { o.loader = append(o.loader, loader...) }
 }
+
+// Health with the health aggregator the loaders are registered into.
+func Health(h *health.Health) Option {
+	return func(o *Options) { o.health = h }
+}
+
+// StopTimeout with the timeout of stopping each loader.
+func StopTimeout(timeout time.Duration) Option {
+	return func(o *Options) { o.stopTimeout = timeout }
+}
@@ -3,11 +3,31 @@ package runtime
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/nextmicro/logger"
+	"github.com/nextmicro/next/pkg/health"
+	"github.com/nextmicro/next/runtime/loader"
 )
 
 type Runtime struct {
 	// options configure runtime
 	options Options
+	// loaders sorted in topological order
+	loaders []loader.Loader
+	// timings of init and start
+	timings map[string]*timing
+}
+
+type timing struct {
+	init    time.Duration
+	start   time.Duration
+	started bool
+	err     error
 }
 
 // NewRuntime creates new local runtime and returns it
@@ -16,6 +36,7 @@ func NewRuntime(opts ...Option) *Runtime {
 	options := applyOptions(opts...)
 	return &Runtime{
 		options: options,
+		timings: make(map[string]*timing),
 	}
 }
 
@@ -25,47 +46,195 @@ func (r *Runtime) Init(opts ...Option) error {
 		o(&r.options)
 	}
 
-	for _, load := range r.options.loader {
+	loaders, err := sortLoaders(r.options.loader)
+	if err != nil {
+		return err
+	}
+	r.loaders = loaders
+
+	for _, load := range r.loaders {
+		start := time.Now()
 		if err := load.Init(); err != nil {
 			return errors.New(load.String() + ": init failed " + err.Error())
 		}
+		r.timings[load.String()] = &timing{init: time.Since(start)}
 	}
 
 	return nil
 }
 
-// Start runtime start
+// Start runtime start, the independent loaders are started in parallel
+// and each loader is started after its dependencies. No loader is started after
+// the first failure, and the started loaders are stopped in reverse order.
 func (r *Runtime) Start(ctx context.Context) error {
-	for _, load := range r.options.loader {
-		if !load.Initialized() {
-			continue
-		}
-		err := load.Start(ctx)
-		if err != nil {
-			return errors.New(load.String() + ": start failed " + err.Error())
+	var (
+		wg      sync.WaitGroup
+		errs    = make([]error, len(r.loaders))
+		started = make([]bool, len(r.loaders))
+		done    = make(map[string]chan struct{}, len(r.loaders))
+		failed  atomic.Bool
+	)
+	for _, load := range r.loaders {
+		done[load.String()] = make(chan struct{})
+	}
+
+	for i, load := range r.loaders {
+		wg.Add(1)
+		go func(i int, load loader.Loader) {
+			defer wg.Done()
+			defer close(done[load.String()])
+
+			for _, dep := range dependencies(load) {
+				<-done[dep]
+			}
+			if failed.Load() {
+				return
+			}
+			if started[i], errs[i] = r.start(ctx, load); errs[i] != nil {
+				failed.Store(true)
+			}
+		}(i, load)
+	}
+	wg.Wait()
+
+	r.report()
+	err := errors.Join(errs...)
+	if err == nil {
+		return nil
+	}
+
+	loaders := make([]loader.Loader, 0, len(r.loaders))
+	for i, load := range r.loaders {
+		if started[i] {
+			loaders = append(loaders, load)
 		}
+	}
+	return errors.Join(err, r.stopLoaders(ctx, loaders))
+}
+
+// start starts the loader, started reports whether it is started and must be stopped.
+func (r *Runtime) start(ctx context.Context, load loader.Loader) (started bool, err error) {
+	if !load.Initialized() {
+		return false, nil
+	}
 
-		err = load.Watch()
-		if err != nil {
-			return errors.New(load.String() + ": watch failed " + err.Error())
+	start := time.Now()
+	defer func() {
+		if t, ok := r.timings[load.String()]; ok {
+			t.start = time.Since(start)
+			t.started = started
+			t.err = err
 		}
+	}()
+
+	if err = load.Start(ctx); err != nil {
+		return false, errors.New(load.String() + ": start failed " + err.Error())
 	}
 
-	return nil
+	if err = load.Watch(); err != nil {
+		return true, errors.New(load.String() + ": watch failed " + err.Error())
+	}
+
+	if hc, ok := load.(loader.HealthChecker); ok && r.options.health != nil {
+		r.options.health.Register(load.String(), health.CheckerFunc(hc.Health))
+	}
+	return true, nil
 }
 
-// Stop stops runtime
-func (r *Runtime) Stop(ctx context.Context) (err error) {
-	// reverse stop
-	for i := len(r.options.loader) - 1; i >= 0; i-- {
-		load := r.options.loader[i]
-		if !load.Initialized() {
+// report logs the init and start durations of each loader.
+func (r *Runtime) report() {
+	var b strings.Builder
+	for _, load := range r.loaders {
+		t, ok := r.timings[load.String()]
+		if !ok {
 			continue
 		}
-		if err = load.Stop(ctx); err != nil && !errors.Is(err, context.Canceled) {
-			err = errors.New(load.String() + ": stop failed " + err.Error())
+		status := "ok"
+		if !load.Initialized() {
+			status = "disabled"
+		} else if t.err != nil {
+			status = "failed"
+		} else if !t.started {
+			status = "skipped"
 		}
+		_, _ = fmt.Fprintf(&b, "\n\t%-16s init: %-12s start: %-12s %s", load.String(), t.init, t.start, status)
 	}
+	log.Infof("Runtime loaders started:%s", b.String())
+}
+
+// Health returns the health aggregator of the runtime.
+func (r *Runtime) Health() *health.Health {
+	return r.options.health
+}
+
+// Stop stops runtime, each loader is stopped before its dependencies
+// within the stop timeout, all the failures are returned.
+func (r *Runtime) Stop(ctx context.Context) error {
+	return r.stopLoaders(ctx, r.loaders)
+}
 
-	return err
+// stopLoaders stops the loaders sorted in topological order, each loader is stopped before its dependencies.
+func (r *Runtime) stopLoaders(ctx context.Context, loaders []loader.Loader) error {
+	var (
+		wg         sync.WaitGroup
+		errs       = make([]error, len(loaders))
+		done       = make(map[string]chan struct{}, len(loaders))
+		dependents = make(map[string][]string, len(loaders))
+	)
+	for _, load := range loaders {
+		done[load.String()] = make(chan struct{})
+		for _, dep := range dependencies(load) {
+			dependents[dep] = append(dependents[dep], load.String())
+		}
+	}
+
+	// reverse stop
+	for i := len(loaders) - 1; i >= 0; i-- {
+		wg.Add(1)
+		go func(i int, load loader.Loader) {
+			defer wg.Done()
+			defer close(done[load.String()])
+
+			for _, name := range dependents[load.String()] {
+				<-done[name]
+			}
+			errs[i] = r.stop(ctx, load)
+		}(i, loaders[i])
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (r *Runtime) stop(ctx context.Context, load loader.Loader) error {
+	if !load.Initialized() {
+		return nil
+	}
+	if _, ok := load.(loader.HealthChecker); ok && r.options.health != nil {
+		r.options.health.Unregister(load.String())
+	}
+
+	// the loaders are stopped even if the app context is canceled, within the stop timeout.
+	ctx = context.WithoutCancel(ctx)
+	if r.options.stopTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.options.stopTimeout)
+		defer cancel()
+	}
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- load.Stop(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		return errors.New(load.String() + ": stop timeout after " + r.options.stopTimeout.String())
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return errors.New(load.String() + ": stop failed " + err.Error())
+	}
+	return nil
 }
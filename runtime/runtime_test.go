@@ -0,0 +1,158 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nextmicro/next/runtime/loader"
+)
+
+type testLoader struct {
+	name     string
+	deps     []string
+	startErr error
+	stopWait time.Duration
+
+	mu     *sync.Mutex
+	events *[]string
+}
+
+func (l *testLoader) Initialized() bool           { return true }
+func (l *testLoader) Init(...loader.Option) error { return nil }
+func (l *testLoader) Watch() error                { return nil }
+func (l *testLoader) String() string              { return l.name }
+func (l *testLoader) Dependencies() []string      { return l.deps }
+
+func (l *testLoader) record(event string) {
+	l.mu.Lock()
+	*l.events = append(*l.events, event)
+	l.mu.Unlock()
+}
+
+func (l *testLoader) Start(_ context.Context) error {
+	l.record("start " + l.name)
+	return l.startErr
+}
+
+func (l *testLoader) Stop(_ context.Context) error {
+	if l.stopWait > 0 {
+		time.Sleep(l.stopWait)
+	}
+	l.record("stop " + l.name)
+	return nil
+}
+
+func newTestRuntime(loaders ...loader.Loader) *Runtime {
+	r := NewRuntime(StopTimeout(50 * time.Millisecond))
+	r.options.loader = loaders
+	r.options.health = nil
+	return r
+}
+
+func indexOf(events []string, event string) int {
+	for i, e := range events {
+		if e == event {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestRuntimeOrder(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	r := newTestRuntime(
+		&testLoader{name: "broker", deps: []string{"logger", "tracing"}, mu: &mu, events: &events},
+		&testLoader{name: "tracing", deps: []string{"logger"}, mu: &mu, events: &events},
+		&testLoader{name: "logger", mu: &mu, events: &events},
+		&testLoader{name: "registry", deps: []string{"logger"}, mu: &mu, events: &events},
+	)
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	before := [][2]string{
+		{"start logger", "start tracing"},
+		{"start logger", "start registry"},
+		{"start tracing", "start broker"},
+		{"stop broker", "stop tracing"},
+		{"stop tracing", "stop logger"},
+		{"stop registry", "stop logger"},
+	}
+	for _, b := range before {
+		if i, j := indexOf(events, b[0]), indexOf(events, b[1]); i < 0 || j < 0 || i > j {
+			t.Errorf("expect %q before %q, got %v", b[0], b[1], events)
+		}
+	}
+}
+
+func TestRuntimeStartError(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	r := newTestRuntime(
+		&testLoader{name: "logger", mu: &mu, events: &events},
+		&testLoader{name: "tracing", deps: []string{"logger"}, mu: &mu, events: &events},
+		&testLoader{name: "registry", deps: []string{"tracing"}, startErr: errors.New("boom"), mu: &mu, events: &events},
+		&testLoader{name: "broker", deps: []string{"registry"}, mu: &mu, events: &events},
+	)
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+	err := r.Start(context.Background())
+	if err == nil || err.Error() != "registry: start failed boom" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the started loaders are stopped in reverse order
+	expect := []string{"start logger", "start tracing", "start registry", "stop tracing", "stop logger"}
+	if strings.Join(events, ",") != strings.Join(expect, ",") {
+		t.Fatalf("expect %v, got %v", expect, events)
+	}
+}
+
+func TestRuntimeStopTimeout(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []string
+	)
+	r := newTestRuntime(
+		&testLoader{name: "a", stopWait: time.Second, mu: &mu, events: &events},
+		&testLoader{name: "b", stopWait: time.Second, mu: &mu, events: &events},
+	)
+	if err := r.Init(); err != nil {
+		t.Fatal(err)
+	}
+	err := r.Stop(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "a: stop timeout") || !strings.Contains(err.Error(), "b: stop timeout") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSortLoaders(t *testing.T) {
+	_, err := sortLoaders([]loader.Loader{
+		&testLoader{name: "a", deps: []string{"b"}},
+		&testLoader{name: "b", deps: []string{"a"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Fatalf("expect cycle error, got %v", err)
+	}
+
+	_, err = sortLoaders([]loader.Loader{&testLoader{name: "a", deps: []string{"c"}}})
+	if err == nil || !strings.Contains(err.Error(), "unknown loader c") {
+		t.Fatalf("expect unknown loader error, got %v", err)
+	}
+}
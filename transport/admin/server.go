@@ -0,0 +1,235 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"sync"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	log "github.com/nextmicro/logger"
+	"github.com/nextmicro/next/pkg/env"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	_ transport.Server = (*Server)(nil)
+	_ http.Handler     = (*Server)(nil)
+)
+
+// defaultAddress is loopback only, pprof and the build info should not be exposed by default.
+const defaultAddress = "127.0.0.1:6060"
+
+// CheckFunc reports the health of the service, a nil error means healthy.
+type CheckFunc func(ctx context.Context) error
+
+// ServerOption is an admin server option.
+type ServerOption func(*Server)
+
+// Network with server network.
+func Network(network string) ServerOption {
+	return func(s *Server) {
+		s.network = network
+	}
+}
+
+// Address with server address.
+func Address(addr string) ServerOption {
+	return func(s *Server) {
+		s.address = addr
+	}
+}
+
+// Service with the service identity shown in /buildinfo.
+func Service(id, name, version string) ServerOption {
+	return func(s *Server) {
+		s.id = id
+		s.name = name
+		s.version = version
+	}
+}
+
+// Liveness with the check of /healthz.
+func Liveness(fn CheckFunc) ServerOption {
+	return func(s *Server) {
+		s.liveness = fn
+	}
+}
+
+// Readiness with the check of /readyz.
+func Readiness(fn CheckFunc) ServerOption {
+	return func(s *Server) {
+		s.readiness = fn
+	}
+}
+
+// ConfigOrigins with the origins of the config keys shown in /debug/config/origins, see config.Origins.
+func ConfigOrigins(fn func() (map[string]string, error)) ServerOption {
+	return func(s *Server) {
+		s.origins = fn
+	}
+}
+
+// BuildInfo is the response of /buildinfo.
+type BuildInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	GitTag      string `json:"git_tag"`
+	GitCommit   string `json:"git_commit"`
+	BuildTime   string `json:"build_time"`
+	GoVersion   string `json:"go_version"`
+	AppVersion  string `json:"app_version"`
+	StartTime   string `json:"start_time"`
+	Environment string `json:"environment"`
+}
+
+// Server is an admin HTTP server, it serves:
+//
+//	/metrics                prometheus metrics
+//	/debug/pprof/*          pprof profiles
+//	/healthz                liveness
+//	/readyz                 readiness
+//	/buildinfo              build info
+//	/debug/config/origins   the config source of each config key, with ConfigOrigins
+//
+// The server is not registered to the registry.
+type Server struct {
+	*http.Server
+	mux       *http.ServeMux
+	network   string
+	address   string
+	id        string
+	name      string
+	version   string
+	liveness  CheckFunc
+	readiness CheckFunc
+	origins   func() (map[string]string, error)
+
+	mu  sync.Mutex
+	lis net.Listener
+}
+
+// NewServer creates an admin server by options.
+func NewServer(opts ...ServerOption) *Server {
+	s := &Server{
+		network: "tcp",
+		address: defaultAddress,
+		mux:     http.NewServeMux(),
+	}
+	for _, o := range opts {
+		o(s)
+	}
+
+	s.mux.Handle("/metrics", promhttp.Handler())
+	s.mux.HandleFunc("/debug/pprof/", pprof.Index)
+	s.mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	s.mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	s.mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	s.mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	s.mux.HandleFunc("/healthz", s.check(func() CheckFunc { return s.liveness }))
+	s.mux.HandleFunc("/readyz", s.check(func() CheckFunc { return s.readiness }))
+	s.mux.HandleFunc("/buildinfo", s.buildInfo)
+	if s.origins != nil {
+		s.mux.HandleFunc("/debug/config/origins", s.configOrigins)
+	}
+
+	s.Server = &http.Server{Handler: s.mux}
+	return s
+}
+
+// Handle registers an extra admin handler for the given pattern.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// ServeHTTP should write reply headers and data to the ResponseWriter and then return.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mux.ServeHTTP(w, req)
+}
+
+func (s *Server) check(getter func() CheckFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if fn := getter(); fn != nil {
+			if err := fn(req.Context()); err != nil {
+				http.Error(w, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte("ok"))
+	}
+}
+
+func (s *Server) buildInfo(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(BuildInfo{
+		ID:          s.id,
+		Name:        s.name,
+		Version:     s.version,
+		GitTag:      env.GitTag(),
+		GitCommit:   env.GitCommit(),
+		BuildTime:   env.BuildTime(),
+		GoVersion:   env.GoVersion(),
+		AppVersion:  env.AppVersion(),
+		StartTime:   env.StartTime(),
+		Environment: env.DeployEnvironment(),
+	})
+}
+
+func (s *Server) configOrigins(w http.ResponseWriter, _ *http.Request) {
+	origins, err := s.origins()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(origins)
+}
+
+// Start start the admin server.
+func (s *Server) Start(ctx context.Context) error {
+	lis, err := s.listen()
+	if err != nil {
+		return err
+	}
+	s.BaseContext = func(net.Listener) context.Context {
+		return ctx
+	}
+	log.Infof("[Admin] server listening on: %s", lis.Addr().String())
+	if err = s.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Stop stop the admin server.
+func (s *Server) Stop(ctx context.Context) error {
+	log.Info("[Admin] server stopping")
+	return s.Shutdown(ctx)
+}
+
+// Addr returns the listening address, it listens on the address if the server is not started.
+func (s *Server) Addr() (net.Addr, error) {
+	lis, err := s.listen()
+	if err != nil {
+		return nil, err
+	}
+	return lis.Addr(), nil
+}
+
+func (s *Server) listen() (net.Listener, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lis == nil {
+		lis, err := net.Listen(s.network, s.address)
+		if err != nil {
+			return nil, err
+		}
+		s.lis = lis
+	}
+	return s.lis, nil
+}
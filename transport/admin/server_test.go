@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	var ready bool
+	srv := NewServer(
+		Address("127.0.0.1:0"),
+		Service("1", "greeter", "v1.0.0"),
+		Readiness(func(context.Context) error {
+			if !ready {
+				return errors.New("not ready")
+			}
+			return nil
+		}),
+	)
+
+	tests := []struct {
+		path string
+		code int
+	}{
+		{"/metrics", http.StatusOK},
+		{"/debug/pprof/", http.StatusOK},
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+		{"/buildinfo", http.StatusOK},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, test.path, nil))
+		if w.Code != test.code {
+			t.Errorf("%s: expect %d, got %d", test.path, test.code, w.Code)
+		}
+	}
+
+	ready = true
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("readyz: expect %d, got %d", http.StatusOK, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/buildinfo", nil))
+	var info BuildInfo
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatal(err)
+	}
+	if info.Name != "greeter" || info.Version != "v1.0.0" || info.GoVersion == "" {
+		t.Errorf("unexpected build info: %+v", info)
+	}
+}
+
+func TestServerConfigOrigins(t *testing.T) {
+	w := httptest.NewRecorder()
+	NewServer().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/config/origins", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expect %d without the origins, got %d", http.StatusNotFound, w.Code)
+	}
+
+	srv := NewServer(ConfigOrigins(func() (map[string]string, error) {
+		return map[string]string{"server.http.addr": "config.dev.yaml"}, nil
+	}))
+	w = httptest.NewRecorder()
+	srv.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/config/origins", nil))
+	var origins map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &origins); err != nil {
+		t.Fatal(err)
+	}
+	if origins["server.http.addr"] != "config.dev.yaml" {
+		t.Errorf("unexpected origins: %v", origins)
+	}
+}
+
+func TestServerStartStop(t *testing.T) {
+	srv := NewServer(Address("127.0.0.1:0"))
+	addr, err := srv.Addr()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srv.Start(context.Background()); err != nil {
+			panic(err)
+		}
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	resp, err := http.Get("http://" + addr.String() + "/healthz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expect %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if err = srv.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-kratos/kratos/v2/registry"
 	"github.com/go-kratos/kratos/v2/selector"
 	"github.com/go-kratos/kratos/v2/transport"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
 )
 
 const (
@@ -69,10 +70,17 @@ func (p *balancerPicker) Pick(info balancer.PickInfo) (balancer.PickResult, erro
 		}
 	}
 
+	filters = nodeselector.Filters(info.Ctx, filters)
+
 	n, done, err := p.selector.Select(info.Ctx, selector.WithNodeFilter(filters...))
 	if err != nil {
 		return balancer.PickResult{}, err
 	}
+	if err = nodeselector.Selected(info.Ctx, n); err != nil {
+		done(info.Ctx, selector.DoneInfo{Err: err})
+		// the status error drops the call instead of picking again
+		return balancer.PickResult{}, err
+	}
 
 	return balancer.PickResult{
 		SubConn: n.(*grpcNode).subConn,
@@ -4,11 +4,12 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-kratos/kratos/v2"
 	v1 "github.com/nextmicro/next/api/config/v1"
 	chain "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/pkg/caller"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	grpcinsecure "google.golang.org/grpc/credentials/insecure"
@@ -66,6 +67,13 @@ func WithMiddleware(m ...middleware.Middleware) ClientOption {
 	}
 }
 
+// WithStreamMiddleware with client stream middleware.
+func WithStreamMiddleware(m ...chain.StreamMiddleware) ClientOption {
+	return func(o *clientOptions) {
+		o.streamMiddleware = m
+	}
+}
+
 // WithDiscovery with client discovery.
 func WithDiscovery(d registry.Discovery) ClientOption {
 	return func(o *clientOptions) {
@@ -148,6 +156,7 @@ type clientOptions struct {
 	timeout                time.Duration
 	discovery              registry.Discovery
 	middleware             []middleware.Middleware
+	streamMiddleware       []chain.StreamMiddleware
 	ints                   []grpc.UnaryClientInterceptor
 	streamInts             []grpc.StreamClientInterceptor
 	grpcOpts               []grpc.DialOption
@@ -185,18 +194,30 @@ func dial(ctx context.Context, insecure bool, opts ...ClientOption) (*grpc.Clien
 		options.endpoint = options.cfg.GetEndpoint()
 	}
 
-	serverMs := buildMiddlewareDialOptions(options.cfg)
+	serverMs, err := buildMiddlewareDialOptions(options.cfg)
+	if err != nil {
+		return nil, err
+	}
 	// server middleware first
 	if len(serverMs) > 0 {
 		userMs := options.middleware
 		options.middleware = append(serverMs, userMs...)
 	}
 
+	serverStreamMs, err := buildStreamMiddlewareDialOptions(options.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(serverStreamMs) > 0 {
+		userMs := options.streamMiddleware
+		options.streamMiddleware = append(serverStreamMs, userMs...)
+	}
+
 	ints := []grpc.UnaryClientInterceptor{
 		unaryClientInterceptor(options.middleware, options.timeout, options.filters),
 	}
 	sints := []grpc.StreamClientInterceptor{
-		streamClientInterceptor(options.filters),
+		streamClientInterceptor(options.streamMiddleware, options.filters),
 	}
 
 	if len(options.ints) > 0 {
@@ -235,14 +256,35 @@ func dial(ctx context.Context, insecure bool, opts ...ClientOption) (*grpc.Clien
 }
 
 // buildMiddlewareDialOptions build dial options.
-func buildMiddlewareDialOptions(cfg *v1.GRPCClient) []middleware.Middleware {
-	ms := make([]middleware.Middleware, 0, len(cfg.GetMiddlewares()))
-	if cfg != nil && cfg.GetMiddlewares() != nil {
-		serverMs, _ := chain.BuildMiddleware("grpc.client", cfg.GetMiddlewares())
-		ms = append(ms, serverMs...)
-	}
+func buildMiddlewareDialOptions(cfg *v1.GRPCClient) ([]middleware.Middleware, error) {
+	return chain.BuildMiddleware("grpc.client", cfg.GetMiddlewares(), chain.Strict(cfg.GetStrictMiddlewares()))
+}
 
-	return ms
+// buildStreamMiddlewareDialOptions build stream dial options.
+func buildStreamMiddlewareDialOptions(cfg *v1.GRPCClient) ([]chain.StreamMiddleware, error) {
+	return chain.BuildStreamMiddleware("grpc.client", cfg.GetMiddlewares(), chain.Strict(cfg.GetStrictMiddlewares()))
+}
+
+// newRequestHeader returns the request header with the local caller identity.
+func newRequestHeader(ctx context.Context) headerCarrier {
+	header := headerCarrier{}
+	caller.Inject(ctx, header)
+	return header
+}
+
+// newOutgoingContext appends the transport request header to the outgoing metadata.
+func newOutgoingContext(ctx context.Context) context.Context {
+	tr, ok := transport.FromClientContext(ctx)
+	if !ok {
+		return ctx
+	}
+	header := tr.RequestHeader()
+	keys := header.Keys()
+	keyvals := make([]string, 0, len(keys))
+	for _, k := range keys {
+		keyvals = append(keyvals, k, header.Get(k))
+	}
+	return grpcmd.AppendToOutgoingContext(ctx, keyvals...)
 }
 
 func unaryClientInterceptor(ms []middleware.Middleware, timeout time.Duration, filters []selector.NodeFilter) grpc.UnaryClientInterceptor {
@@ -250,7 +292,7 @@ func unaryClientInterceptor(ms []middleware.Middleware, timeout time.Duration, f
 		ctx = transport.NewClientContext(ctx, &Transport{
 			endpoint:    cc.Target(),
 			operation:   method,
-			reqHeader:   headerCarrier{},
+			reqHeader:   newRequestHeader(ctx),
 			nodeFilters: filters,
 		})
 		if timeout > 0 {
@@ -258,42 +300,43 @@ func unaryClientInterceptor(ms []middleware.Middleware, timeout time.Duration, f
 			ctx, cancel = context.WithTimeout(ctx, timeout)
 			defer cancel()
 		}
+		var newReply atomic.Bool
 		h := func(ctx context.Context, req interface{}) (interface{}, error) {
-			if tr, ok := transport.FromClientContext(ctx); ok {
-				header := tr.RequestHeader()
-				keys := header.Keys()
-				keyvals := make([]string, 0, len(keys))
-				for _, k := range keys {
-					keyvals = append(keyvals, k, header.Get(k))
-				}
-				app, ok := kratos.FromContext(ctx)
-				if ok {
-					keyvals = append(keyvals, "x-md-local-caller", app.Name())
-				}
-				ctx = grpcmd.AppendToOutgoingContext(ctx, keyvals...)
+			out := reply
+			if chain.IsNewReply(ctx) {
+				out = chain.NewReply(reply)
+				newReply.Store(true)
 			}
-			return reply, invoker(ctx, method, req, reply, cc, opts...)
+			return out, invoker(newOutgoingContext(ctx), method, req, out, cc, opts...)
 		}
 		if len(ms) > 0 {
 			h = middleware.Chain(ms...)(h)
 		}
 		var p selector.Peer
 		ctx = selector.NewPeerContext(ctx, &p)
-		_, err := h(ctx, req)
+		out, err := h(ctx, req)
+		if err == nil && newReply.Load() {
+			chain.CopyReply(reply, out)
+		}
 		return err
 	}
 }
 
-func streamClientInterceptor(filters []selector.NodeFilter) grpc.StreamClientInterceptor {
+func streamClientInterceptor(ms []chain.StreamMiddleware, filters []selector.NodeFilter) grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) { // nolint
 		ctx = transport.NewClientContext(ctx, &Transport{
 			endpoint:    cc.Target(),
 			operation:   method,
-			reqHeader:   headerCarrier{},
+			reqHeader:   newRequestHeader(ctx),
 			nodeFilters: filters,
 		})
 		var p selector.Peer
 		ctx = selector.NewPeerContext(ctx, &p)
-		return streamer(ctx, desc, cc, method, opts...)
+		if len(ms) == 0 {
+			return streamer(newOutgoingContext(ctx), desc, cc, method, opts...)
+		}
+		return newClientStream(ctx, ms, func(ctx context.Context) (grpc.ClientStream, error) {
+			return streamer(newOutgoingContext(ctx), desc, cc, method, opts...)
+		})
 	}
 }
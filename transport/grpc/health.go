@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// watchHealth updates the serving status of the server and each service from the health checks.
+func (s *Server) watchHealth(ctx context.Context) {
+	if s.healthCheck == nil || s.customHealth {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	s.healthMu.Lock()
+	s.healthCancel = cancel
+	s.healthMu.Unlock()
+	last := make(map[string]grpc_health_v1.HealthCheckResponse_ServingStatus)
+	s.updateHealth(ctx, last)
+	go func() {
+		ticker := time.NewTicker(s.healthInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.updateHealth(ctx, last)
+			}
+		}
+	}()
+}
+
+// updateHealth sets the serving status, the changes from `last` are logged.
+func (s *Server) updateHealth(ctx context.Context, last map[string]grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	services := []string{""}
+	for name := range s.GetServiceInfo() {
+		services = append(services, name)
+	}
+
+	for _, service := range services {
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		err := s.healthCheck.CheckService(ctx, service)
+		if err != nil {
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		if prev, ok := last[service]; !ok || prev != status {
+			if err != nil {
+				log.Warnf("[GRPC] service [%s] health status: %s, error: %v", service, status, err)
+			} else if ok {
+				log.Infof("[GRPC] service [%s] health status: %s", service, status)
+			}
+			last[service] = status
+		}
+		s.health.SetServingStatus(service, status)
+	}
+}
+
+// stopHealth stops updating the health status, it may run concurrently with Start.
+func (s *Server) stopHealth() {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	if s.healthCancel != nil {
+		s.healthCancel()
+	}
+}
+
+// Drain sets the health status NOT_SERVING, the health checks no longer update it.
+func (s *Server) Drain(_ context.Context) error {
+	s.stopHealth()
+	s.health.Shutdown()
+	log.Info("[GRPC] server draining")
+	return nil
+}
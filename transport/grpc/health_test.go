@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/nextmicro/next/internal/testdata/helloworld"
+	healthcheck "github.com/nextmicro/next/pkg/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthCheck(t *testing.T) {
+	var healthy atomic.Bool
+	h := healthcheck.New(healthcheck.WithCacheTTL(0))
+	h.Register("db", healthcheck.CheckerFunc(func(context.Context) error {
+		if !healthy.Load() {
+			return errors.New("db down")
+		}
+		return nil
+	}), healthcheck.Services("helloworld.Greeter"))
+
+	srv := NewServer(HealthCheck(h), HealthCheckInterval(10*time.Millisecond))
+	pb.RegisterGreeterServer(srv, &server{})
+	if _, err := srv.Endpoint(); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srv.Start(context.Background()); err != nil {
+			panic(err)
+		}
+	}()
+	defer func() {
+		_ = srv.Stop(context.Background())
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	check := func(service string, expect grpc_health_v1.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := srv.health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Status != expect {
+			t.Errorf("%q: expect %s, got %s", service, expect, resp.Status)
+		}
+	}
+	check("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	check("helloworld.Greeter", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	check("grpc.health.v1.Health", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	healthy.Store(true)
+	time.Sleep(time.Millisecond * 100)
+	check("", grpc_health_v1.HealthCheckResponse_SERVING)
+	check("helloworld.Greeter", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// the health checks no longer update the status after drain
+	if err := srv.Drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 50)
+	check("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	check("helloworld.Greeter", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestHealthDrainWhileStarting(t *testing.T) {
+	srv := NewServer(HealthCheck(healthcheck.New()), HealthCheckInterval(time.Millisecond))
+	if _, err := srv.Endpoint(); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = srv.Start(context.Background())
+	}()
+	// the health checks started by Start are stopped by Drain and Stop, run with -race
+	if err := srv.Drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond * 10)
+	if err := srv.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
+	chain "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/pkg/caller"
 )
 
 // unaryServerInterceptor is a gRPC unary server interceptor
@@ -28,6 +30,9 @@ func (s *Server) unaryServerInterceptor() grpc.UnaryServerInterceptor {
 			tr.endpoint = s.endpoint.String()
 		}
 		ctx = transport.NewServerContext(ctx, tr)
+		if c, ok := caller.Extract(tr.reqHeader); ok {
+			ctx = caller.NewContext(ctx, c)
+		}
 		if s.timeout > 0 {
 			ctx, cancel = context.WithTimeout(ctx, s.timeout)
 			defer cancel()
@@ -63,6 +68,25 @@ func (w *wrappedStream) Context() context.Context {
 	return w.ctx
 }
 
+// serverStream is the grpc.ServerStream passed to the handler,
+// the messages are sent and received through the stream middleware chain.
+type serverStream struct {
+	grpc.ServerStream
+	stream chain.Stream
+}
+
+func (w *serverStream) Context() context.Context {
+	return w.stream.Context()
+}
+
+func (w *serverStream) SendMsg(m interface{}) error {
+	return w.stream.SendMsg(m)
+}
+
+func (w *serverStream) RecvMsg(m interface{}) error {
+	return w.stream.RecvMsg(m)
+}
+
 // streamServerInterceptor is a gRPC stream server interceptor
 func (s *Server) streamServerInterceptor() grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
@@ -70,16 +94,26 @@ func (s *Server) streamServerInterceptor() grpc.StreamServerInterceptor {
 		defer cancel()
 		md, _ := grpcmd.FromIncomingContext(ctx)
 		replyHeader := grpcmd.MD{}
-		ctx = transport.NewServerContext(ctx, &Transport{
-			endpoint:    s.endpoint.String(),
+		tr := &Transport{
 			operation:   info.FullMethod,
 			reqHeader:   headerCarrier(md),
 			replyHeader: headerCarrier(replyHeader),
-		})
-
-		ws := NewWrappedStream(ctx, ss)
+		}
+		if s.endpoint != nil {
+			tr.endpoint = s.endpoint.String()
+		}
+		ctx = transport.NewServerContext(ctx, tr)
+		if c, ok := caller.Extract(tr.reqHeader); ok {
+			ctx = caller.NewContext(ctx, c)
+		}
 
-		err := handler(srv, ws)
+		h := func(ctx context.Context, stream chain.Stream) error {
+			return handler(srv, &serverStream{ServerStream: ss, stream: stream})
+		}
+		if next := s.streamMatcher.Match(tr.Operation()); len(next) > 0 {
+			h = chain.StreamChain(next...)(h)
+		}
+		err := h(ctx, NewWrappedStream(ctx, ss))
 		if len(replyHeader) > 0 {
 			_ = grpc.SetHeader(ctx, replyHeader)
 		}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/middleware"
+	pb "github.com/nextmicro/next/internal/testdata/helloworld"
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/grpc"
+)
+
+func TestNewReply(t *testing.T) {
+	srv := NewServer()
+	pb.RegisterGreeterServer(srv, &server{})
+	u, err := srv.Endpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srv.Start(context.Background()); err != nil {
+			panic(err)
+		}
+	}()
+	defer func() {
+		_ = srv.Stop(context.Background())
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	original := &pb.HelloReply{}
+	conn, err := DialInsecure(context.Background(),
+		WithEndpoint(u.Host),
+		WithOptions(grpc.WithBlock()),
+		WithMiddleware(func(handler middleware.Handler) middleware.Handler {
+			return func(ctx context.Context, req interface{}) (interface{}, error) {
+				reply, err := handler(chain.NewReplyContext(ctx), req)
+				if reply == interface{}(original) {
+					t.Error("expect the reply decoded into a new object")
+				}
+				return reply, err
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err = conn.Invoke(context.Background(), "/helloworld.Greeter/SayHello", &pb.HelloRequest{Name: "next"}, original); err != nil {
+		t.Fatal(err)
+	}
+	if original.Message != "Hello next" {
+		t.Errorf("expect the reply copied back, got %q", original.Message)
+	}
+}
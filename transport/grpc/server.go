@@ -3,16 +3,21 @@ package grpc
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"net"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	config "github.com/nextmicro/next/api/config/v1"
 	conf "github.com/nextmicro/next/config"
 	"github.com/nextmicro/next/internal/endpoint"
 	"github.com/nextmicro/next/internal/host"
 	"github.com/nextmicro/next/internal/matcher"
 	customMiddleware "github.com/nextmicro/next/middleware"
 
+	healthcheck "github.com/nextmicro/next/pkg/health"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/admin"
 	"google.golang.org/grpc/credentials"
@@ -21,6 +26,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	apimd "github.com/go-kratos/kratos/v2/api/metadata"
+	kconfig "github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
@@ -31,6 +37,11 @@ var (
 	_ transport.Endpointer = (*Server)(nil)
 )
 
+const (
+	serverKey      = "server.grpc"
+	middlewaresKey = serverKey + ".middlewares"
+)
+
 // ServerOption is gRPC server option.
 type ServerOption func(o *Server)
 
@@ -71,7 +82,14 @@ func Logger(_ log.Logger) ServerOption {
 // Middleware with server middleware.
 func Middleware(m ...middleware.Middleware) ServerOption {
 	return func(s *Server) {
-		s.matcher.Use(m...)
+		s.middleware = append(s.middleware, m...)
+	}
+}
+
+// StreamMiddleware with server stream middleware.
+func StreamMiddleware(m ...customMiddleware.StreamMiddleware) ServerOption {
+	return func(s *Server) {
+		s.streamMiddleware = append(s.streamMiddleware, m...)
 	}
 }
 
@@ -82,6 +100,20 @@ func CustomHealth() ServerOption {
 	}
 }
 
+// HealthCheck with the health aggregator driving the health status, nil disables the checks.
+func HealthCheck(h *healthcheck.Health) ServerOption {
+	return func(s *Server) {
+		s.healthCheck = h
+	}
+}
+
+// HealthCheckInterval with the interval the health status is updated.
+func HealthCheckInterval(interval time.Duration) ServerOption {
+	return func(s *Server) {
+		s.healthInterval = interval
+	}
+}
+
 // TLSConfig with TLS config.
 func TLSConfig(c *tls.Config) ServerOption {
 	return func(s *Server) {
@@ -120,23 +152,34 @@ func Options(opts ...grpc.ServerOption) ServerOption {
 // Server is a gRPC server wrapper.
 type Server struct {
 	*grpc.Server
-	baseCtx      context.Context
-	tlsConf      *tls.Config
-	lis          net.Listener
-	err          error
-	network      string
-	address      string
-	endpoint     *url.URL
-	timeout      time.Duration
-	matcher      matcher.Matcher
-	middleware   []middleware.Middleware
-	unaryInts    []grpc.UnaryServerInterceptor
-	streamInts   []grpc.StreamServerInterceptor
-	grpcOpts     []grpc.ServerOption
-	health       *health.Server
-	customHealth bool
-	metadata     *apimd.Server
-	adminClean   func()
+	baseCtx          context.Context
+	tlsConf          *tls.Config
+	lis              net.Listener
+	err              error
+	network          string
+	address          string
+	endpoint         *url.URL
+	timeout          time.Duration
+	matcher          matcher.Matcher
+	middleware       []middleware.Middleware
+	streamMatcher    matcher.StreamMatcher
+	streamMiddleware []customMiddleware.StreamMiddleware
+	entries          atomic.Pointer[[]customMiddleware.Entry[middleware.Middleware]]
+	streamEntries    atomic.Pointer[[]customMiddleware.Entry[customMiddleware.StreamMiddleware]]
+	reloadMu         sync.Mutex
+	generation       *customMiddleware.Generation
+	stopped          bool
+	unaryInts        []grpc.UnaryServerInterceptor
+	streamInts       []grpc.StreamServerInterceptor
+	grpcOpts         []grpc.ServerOption
+	health           *health.Server
+	customHealth     bool
+	healthCheck      *healthcheck.Health
+	healthInterval   time.Duration
+	healthMu         sync.Mutex
+	healthCancel     context.CancelFunc
+	metadata         *apimd.Server
+	adminClean       func()
 }
 
 // NewServer creates a gRPC server by options.
@@ -148,6 +191,11 @@ func NewServer(opts ...ServerOption) *Server {
 		timeout: 1 * time.Second,
 		health:  health.NewServer(),
 		matcher: matcher.New(),
+
+		healthCheck:    healthcheck.DefaultHealth,
+		healthInterval: 5 * time.Second,
+
+		streamMatcher: matcher.NewStream(),
 	}
 	// apply config
 	srv.applyConfig()
@@ -190,27 +238,137 @@ func (s *Server) applyOptions(opts []ServerOption) {
 	}
 }
 
-// buildMiddlewareChain builds the middleware chain.
+// buildMiddlewareChain builds the middleware chain, the config middlewares run before the user middlewares.
 func (s *Server) buildMiddlewareChain() {
-	serverMiddleware := s.buildServerMiddleware()
+	cfg := conf.ApplicationConfig().GetServer().GetGrpc()
+	if err := s.useMiddlewares(cfg.GetMiddlewares(), cfg.GetStrictMiddlewares()); err != nil {
+		s.err = err
+		return
+	}
+	s.watchMiddlewares()
+}
+
+// useMiddlewares builds the config middlewares and swaps them into the matchers,
+// the in-flight requests keep running the previous chain.
+// The middlewares with the same options are reused, the replaced ones are closed once the in-flight requests finish.
+func (s *Server) useMiddlewares(ms []*config.Middleware, strict bool) error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	if s.stopped {
+		return nil
+	}
 
-	if len(serverMiddleware) > 0 {
-		userMs := s.middleware
-		s.middleware = append(serverMiddleware, userMs...)
+	var (
+		prev       []customMiddleware.Entry[middleware.Middleware]
+		prevStream []customMiddleware.Entry[customMiddleware.StreamMiddleware]
+	)
+	if p := s.entries.Load(); p != nil {
+		prev = *p
+	}
+	if p := s.streamEntries.Load(); p != nil {
+		prevStream = *p
+	}
+	entries, err := customMiddleware.Build("grpc.server", ms, customMiddleware.Strict(strict), customMiddleware.Reuse(prev))
+	if err != nil {
+		return err
+	}
+	streamEntries, err := customMiddleware.BuildStream("grpc.server", ms, customMiddleware.Strict(strict), customMiddleware.Reuse(prevStream))
+	if err != nil {
+		closeMiddlewares(entries, prev, nil, nil)
+		return err
 	}
 
-	s.matcher.Use(s.middleware...)
+	g := customMiddleware.NewGeneration()
+	s.entries.Store(&entries)
+	s.matcher.Use(append(append([]middleware.Middleware{g.Middleware()}, customMiddleware.Middlewares(entries)...), s.middleware...)...)
+	s.streamEntries.Store(&streamEntries)
+	s.streamMatcher.Use(append(append([]customMiddleware.StreamMiddleware{g.StreamMiddleware()}, customMiddleware.StreamMiddlewares(streamEntries)...), s.streamMiddleware...)...)
+	if s.generation != nil {
+		s.generation.Retire(func() {
+			closeMiddlewares(prev, entries, prevStream, streamEntries)
+		})
+	}
+	s.generation = g
+	return nil
 }
 
-// buildServerMiddleware builds the server middlewares.
-func (s *Server) buildServerMiddleware() (ms []middleware.Middleware) {
-	cfg := conf.ApplicationConfig().GetServer().GetGrpc()
-	if cfg == nil {
-		return ms
+// closeMiddlewares closes the middlewares of prev not reused by next.
+func closeMiddlewares(prev, next []customMiddleware.Entry[middleware.Middleware],
+	prevStream, nextStream []customMiddleware.Entry[customMiddleware.StreamMiddleware]) {
+	err := errors.Join(customMiddleware.CloseReplaced(prev, next), customMiddleware.CloseReplaced(prevStream, nextStream))
+	if err != nil {
+		log.Errorf("[GRPC] close middlewares error: %v", err)
 	}
+}
 
-	ms, _ = customMiddleware.BuildMiddleware("grpc.server", cfg.GetMiddlewares())
-	return ms
+// closeGeneration closes the config middlewares once the in-flight requests finish, the chain is not reloaded anymore.
+func (s *Server) closeGeneration() {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	s.stopped = true
+	if s.generation == nil {
+		return
+	}
+	var (
+		entries       []customMiddleware.Entry[middleware.Middleware]
+		streamEntries []customMiddleware.Entry[customMiddleware.StreamMiddleware]
+	)
+	if p := s.entries.Load(); p != nil {
+		entries = *p
+	}
+	if p := s.streamEntries.Load(); p != nil {
+		streamEntries = *p
+	}
+	s.generation.Retire(func() {
+		closeMiddlewares(entries, nil, streamEntries, nil)
+	})
+	s.generation = nil
+}
+
+// watchMiddlewares rebuilds the middleware chain when server.grpc.middlewares changes,
+// the invalid middlewares are skipped as on startup, or rejected in strict mode keeping the previous chain.
+func (s *Server) watchMiddlewares() {
+	if conf.DefaultConfig == nil {
+		return
+	}
+
+	err := conf.Watch(middlewaresKey, func(_ string, _ kconfig.Value) {
+		s.reloadMiddlewares()
+	})
+	if err != nil && !errors.Is(err, kconfig.ErrNotFound) {
+		log.Errorf("[GRPC] watch [%s] error: %v", middlewaresKey, err)
+	}
+}
+
+// reloadMiddlewares rebuilds the middleware chain from the current config.
+func (s *Server) reloadMiddlewares() {
+	cfg := &config.GRPCServer{}
+	if err := conf.Value(serverKey).Scan(cfg); err != nil {
+		log.Errorf("[GRPC] watch [%s] scan error: %v", middlewaresKey, err)
+		return
+	}
+	if err := s.useMiddlewares(cfg.GetMiddlewares(), cfg.GetStrictMiddlewares()); err != nil {
+		log.Errorf("[GRPC] watch [%s] invalid middlewares, keep the previous chain: %v", middlewaresKey, err)
+		return
+	}
+	log.Infof("[GRPC] watch [%s] reloaded middlewares: %d", middlewaresKey, len(cfg.GetMiddlewares()))
+}
+
+// MiddlewareChain returns the config middlewares running for the operation in order,
+// the user middlewares follow them.
+func (s *Server) MiddlewareChain(operation string) []string {
+	if entries := s.entries.Load(); entries != nil {
+		return customMiddleware.Describe(*entries, operation)
+	}
+	return nil
+}
+
+// StreamMiddlewareChain returns the config stream middlewares running for the operation in order.
+func (s *Server) StreamMiddlewareChain(operation string) []string {
+	if entries := s.streamEntries.Load(); entries != nil {
+		return customMiddleware.Describe(*entries, operation)
+	}
+	return nil
 }
 
 // buildInterceptors builds the interceptors.
@@ -249,6 +407,15 @@ func (s *Server) Use(selector string, m ...middleware.Middleware) {
 	s.matcher.Add(selector, m...)
 }
 
+// UseStream uses a service stream middleware with selector.
+// selector:
+//   - '/*'
+//   - '/helloworld.v1.Greeter/*'
+//   - '/helloworld.v1.Greeter/SayHelloStream'
+func (s *Server) UseStream(selector string, m ...customMiddleware.StreamMiddleware) {
+	s.streamMatcher.Add(selector, m...)
+}
+
 // Endpoint return a real address to registry endpoint.
 // examples:
 //
@@ -268,6 +435,7 @@ func (s *Server) Start(ctx context.Context) error {
 	s.baseCtx = ctx
 	log.Infof("[GRPC] server listening on: %s", s.lis.Addr().String())
 	s.health.Resume()
+	s.watchHealth(ctx)
 	return s.Serve(s.lis)
 }
 
@@ -276,13 +444,18 @@ func (s *Server) Stop(_ context.Context) error {
 	if s.adminClean != nil {
 		s.adminClean()
 	}
+	s.stopHealth()
 	s.health.Shutdown()
 	s.GracefulStop()
+	s.closeGeneration()
 	log.Info("[GRPC] server stopping")
 	return nil
 }
 
 func (s *Server) listenAndEndpoint() error {
+	if s.err != nil {
+		return s.err
+	}
 	if s.lis == nil {
 		lis, err := net.Listen(s.network, s.address)
 		if err != nil {
@@ -6,15 +6,23 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	config "github.com/nextmicro/next/api/config/v1"
+	conf "github.com/nextmicro/next/config"
 	"github.com/nextmicro/next/internal/matcher"
 	pb "github.com/nextmicro/next/internal/testdata/helloworld"
+	customMiddleware "github.com/nextmicro/next/middleware"
 	"google.golang.org/grpc"
 
+	kconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/config/file"
 	"github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
@@ -294,3 +302,116 @@ func TestListener(t *testing.T) {
 		t.Errorf("expect not empty")
 	}
 }
+
+func TestServer_watchMiddlewares(t *testing.T) {
+	for _, name := range []string{"test.reload.a", "test.reload.b"} {
+		customMiddleware.Register("server."+name, func(*config.Middleware) (middleware.Middleware, error) {
+			return EmptyMiddleware(), nil
+		})
+	}
+	var created, closed atomic.Int64
+	customMiddleware.RegisterCloser("server.test.reload.c", func(*config.Middleware) (middleware.Middleware, customMiddleware.CloseFunc, error) {
+		created.Add(1)
+		return EmptyMiddleware(), func() error {
+			closed.Add(1)
+			return nil
+		}, nil
+	})
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(`
+server:
+  grpc:
+    middlewares:
+      - name: test.reload.c
+`)
+	c := kconfig.New(kconfig.WithSource(file.NewSource(path)))
+	if err := c.Load(); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	old := conf.DefaultConfig
+	conf.DefaultConfig = c
+	defer func() { conf.DefaultConfig = old }()
+
+	srv := &Server{matcher: matcher.New(), streamMatcher: matcher.NewStream()}
+	if err := srv.useMiddlewares([]*config.Middleware{{Name: "test.reload.c"}}, true); err != nil {
+		t.Fatal(err)
+	}
+	srv.watchMiddlewares()
+
+	eventually := func(expected []string) {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		for !reflect.DeepEqual(expected, srv.MiddlewareChain("/test")) {
+			if time.Now().After(deadline) {
+				t.Fatalf("expect %v, got %v", expected, srv.MiddlewareChain("/test"))
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	write(`
+server:
+  grpc:
+    middlewares:
+      - name: test.reload.b
+      - name: test.reload.a
+      - name: test.reload.c
+`)
+	eventually([]string{"test.reload.b", "test.reload.a", "test.reload.c"})
+	// the generation counter runs first
+	if ms := srv.matcher.Match("/test"); len(ms) != 4 {
+		t.Errorf("expect %d, got %d", 4, len(ms))
+	}
+	// the middleware with the same options is reused
+	if created.Load() != 1 || closed.Load() != 0 {
+		t.Errorf("expect 1 created and 0 closed, got %d and %d", created.Load(), closed.Load())
+	}
+
+	// the unknown middleware is rejected in strict mode, keep the previous chain
+	reload := func(content string) {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		c := kconfig.New(kconfig.WithSource(file.NewSource(path)))
+		if err := c.Load(); err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		conf.DefaultConfig = c
+		srv.reloadMiddlewares()
+	}
+	reload(`
+server:
+  grpc:
+    strict_middlewares: true
+    middlewares:
+      - name: test.reload.a
+      - name: test.reload.unknown
+`)
+	eventually([]string{"test.reload.b", "test.reload.a", "test.reload.c"})
+
+	// the unknown middleware is skipped as on startup
+	reload(`
+server:
+  grpc:
+    middlewares:
+      - name: test.reload.unknown
+      - name: test.reload.a
+      - name: test.reload.c
+`)
+	eventually([]string{"test.reload.a", "test.reload.c"})
+
+	srv.closeGeneration()
+	if created.Load() != 1 || closed.Load() != 1 {
+		t.Errorf("expect 1 created and 1 closed, got %d and %d", created.Load(), closed.Load())
+	}
+}
@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/grpc"
+)
+
+// errStreamNotCreated is returned when the stream middleware returns without creating the stream.
+var errStreamNotCreated = errors.New("grpc: stream middleware returned without creating the stream")
+
+// clientStream is the grpc.ClientStream returned to the caller,
+// the messages are sent and received through the stream middleware chain.
+type clientStream struct {
+	grpc.ClientStream
+	stream chain.Stream
+}
+
+func (cs *clientStream) Context() context.Context {
+	return cs.stream.Context()
+}
+
+func (cs *clientStream) SendMsg(m interface{}) error {
+	return cs.stream.SendMsg(m)
+}
+
+func (cs *clientStream) RecvMsg(m interface{}) error {
+	return cs.stream.RecvMsg(m)
+}
+
+// pendingStream is the innermost stream of the client chain,
+// it is bound to the grpc.ClientStream once the stream is created.
+type pendingStream struct {
+	ctx    context.Context
+	stream grpc.ClientStream
+
+	mu  sync.Mutex
+	err error
+}
+
+func (ps *pendingStream) Context() context.Context {
+	if ps.stream != nil {
+		return ps.stream.Context()
+	}
+	return ps.ctx
+}
+
+func (ps *pendingStream) SendMsg(m interface{}) error {
+	err := ps.stream.SendMsg(m)
+	ps.setErr(err)
+	return err
+}
+
+func (ps *pendingStream) RecvMsg(m interface{}) error {
+	err := ps.stream.RecvMsg(m)
+	ps.setErr(err)
+	return err
+}
+
+// setErr records the first error of the stream, io.EOF means the stream finished successfully.
+func (ps *pendingStream) setErr(err error) {
+	if err == nil || errors.Is(err, io.EOF) {
+		return
+	}
+	ps.mu.Lock()
+	if ps.err == nil {
+		ps.err = err
+	}
+	ps.mu.Unlock()
+}
+
+// wait blocks until the stream is finished and returns the stream error.
+func (ps *pendingStream) wait(ctx context.Context) error {
+	// the stream context is canceled by grpc once the stream is finished.
+	<-ps.stream.Context().Done()
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.err != nil {
+		return ps.err
+	}
+	return ctx.Err()
+}
+
+type clientStreamResult struct {
+	stream grpc.ClientStream
+	err    error
+}
+
+// newClientStream runs the stream middleware chain around the whole client stream.
+// The chain runs in its own goroutine until the stream is finished,
+// the stream is returned to the caller as soon as it is created.
+// The stream is created once, the later calls of the handler return chain.ErrStreamHandlerCalled.
+func newClientStream(ctx context.Context, ms []chain.StreamMiddleware, create func(ctx context.Context) (grpc.ClientStream, error)) (grpc.ClientStream, error) {
+	var (
+		called  atomic.Bool
+		created atomic.Bool
+		ps      = &pendingStream{ctx: ctx}
+		result  = make(chan clientStreamResult, 1)
+	)
+	h := func(ctx context.Context, stream chain.Stream) error {
+		if !called.CompareAndSwap(false, true) {
+			return chain.ErrStreamHandlerCalled
+		}
+		cs, err := create(ctx)
+		if err != nil {
+			return err
+		}
+		ps.stream = cs
+		created.Store(true)
+		result <- clientStreamResult{stream: &clientStream{ClientStream: cs, stream: stream}}
+		return ps.wait(ctx)
+	}
+
+	go func() {
+		err := chain.StreamChain(ms...)(h)(ctx, ps)
+		if created.Load() {
+			return
+		}
+		if err == nil {
+			err = errStreamNotCreated
+		}
+		result <- clientStreamResult{err: err}
+	}()
+
+	r := <-result
+	return r.stream, r.err
+}
@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	pb "github.com/nextmicro/next/internal/testdata/helloworld"
+	chain "github.com/nextmicro/next/middleware"
+	"google.golang.org/grpc"
+)
+
+type streamCounter struct {
+	streams int64
+	recv    int64
+	sent    int64
+	done    chan error
+}
+
+func newStreamCounter() *streamCounter {
+	return &streamCounter{done: make(chan error, 1)}
+}
+
+func (c *streamCounter) middleware(handler chain.StreamHandler) chain.StreamHandler {
+	return func(ctx context.Context, stream chain.Stream) error {
+		atomic.AddInt64(&c.streams, 1)
+		err := handler(ctx, chain.WrapStream(stream,
+			chain.OnRecvMsg(func(_ interface{}, err error) {
+				if err == nil {
+					atomic.AddInt64(&c.recv, 1)
+				}
+			}),
+			chain.OnSendMsg(func(_ interface{}, err error) {
+				if err == nil {
+					atomic.AddInt64(&c.sent, 1)
+				}
+			}),
+		))
+		c.done <- err
+		return err
+	}
+}
+
+func TestStreamMiddleware(t *testing.T) {
+	serverCounter := newStreamCounter()
+	srv := NewServer(StreamMiddleware(func(handler chain.StreamHandler) chain.StreamHandler {
+		return func(ctx context.Context, stream chain.Stream) error {
+			if _, ok := transport.FromServerContext(stream.Context()); !ok {
+				t.Error("missing server transport in stream context")
+			}
+			return handler(ctx, stream)
+		}
+	}))
+	// the health check stream of the client is not counted
+	srv.UseStream("/helloworld.Greeter/*", serverCounter.middleware)
+	pb.RegisterGreeterServer(srv, &server{})
+	u, err := srv.Endpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		if err := srv.Start(context.Background()); err != nil {
+			panic(err)
+		}
+	}()
+	defer func() {
+		_ = srv.Stop(context.Background())
+	}()
+	time.Sleep(time.Millisecond * 100)
+
+	clientCounter := newStreamCounter()
+	conn, err := DialInsecure(context.Background(),
+		WithEndpoint(u.Host),
+		WithOptions(grpc.WithBlock()),
+		WithStreamMiddleware(clientCounter.middleware),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	stream, err := pb.NewGreeterClient(conn).SayHelloStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "b"} {
+		if err = stream.Send(&pb.HelloRequest{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = stream.Recv(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = stream.Recv(); err != io.EOF {
+		t.Fatalf("expect io.EOF, got %v", err)
+	}
+
+	for name, c := range map[string]*streamCounter{"server": serverCounter, "client": clientCounter} {
+		select {
+		case err = <-c.done:
+			if err != nil {
+				t.Errorf("%s: expect nil error, got %v", name, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%s: stream middleware not finished", name)
+		}
+		if c.streams != 1 || atomic.LoadInt64(&c.recv) != 2 || atomic.LoadInt64(&c.sent) != 2 {
+			t.Errorf("%s: expect 1 stream with 2/2 messages, got %d stream with %d/%d messages", name, c.streams, c.recv, c.sent)
+		}
+	}
+}
+
+type finishedStream struct {
+	grpc.ClientStream
+	ctx context.Context
+}
+
+func (s *finishedStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamMiddlewareCalledTwice(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var (
+		created int64
+		second  = make(chan error, 1)
+	)
+	_, err := newClientStream(context.Background(), []chain.StreamMiddleware{
+		func(handler chain.StreamHandler) chain.StreamHandler {
+			return func(ctx context.Context, stream chain.Stream) error {
+				err := handler(ctx, stream)
+				second <- handler(ctx, stream)
+				return err
+			}
+		},
+	}, func(context.Context) (grpc.ClientStream, error) {
+		atomic.AddInt64(&created, 1)
+		return &finishedStream{ctx: ctx}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = <-second; err != chain.ErrStreamHandlerCalled {
+		t.Errorf("expect %v, got %v", chain.ErrStreamHandlerCalled, err)
+	}
+	if n := atomic.LoadInt64(&created); n != 1 {
+		t.Errorf("expect 1 stream created, got %d", n)
+	}
+}
+
+func TestStreamMiddlewareNotCreated(t *testing.T) {
+	_, err := newClientStream(context.Background(), []chain.StreamMiddleware{
+		func(chain.StreamHandler) chain.StreamHandler {
+			return func(context.Context, chain.Stream) error {
+				return nil
+			}
+		},
+	}, nil)
+	if err != errStreamNotCreated {
+		t.Errorf("expect %v, got %v", errStreamNotCreated, err)
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-kratos/kratos/v2"
 	v1 "github.com/nextmicro/next/api/config/v1"
 	"github.com/nextmicro/next/internal/host"
 	"github.com/nextmicro/next/internal/httputil"
 	chain "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/pkg/caller"
+	nodeselector "github.com/nextmicro/next/pkg/selector"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 
@@ -239,24 +242,30 @@ func NewClient(ctx context.Context, options ...ClientOption) (*Client, error) {
 		},
 		selector: selectorBuild,
 	}
-	client.buildMiddlewareChain()
+	if err = client.buildMiddlewareChain(); err != nil {
+		return nil, err
+	}
 
 	return client, nil
 }
 
 // buildMiddlewareChain builds the middleware chain.
-func (client *Client) buildMiddlewareChain() {
-	clientMiddleware := client.buildClientMiddleware()
+func (client *Client) buildMiddlewareChain() error {
+	clientMiddleware, err := client.buildClientMiddleware()
+	if err != nil {
+		return err
+	}
 	if len(clientMiddleware) > 0 {
 		userMs := client.opts.middleware
 		client.opts.middleware = append(clientMiddleware, userMs...)
 	}
+	return nil
 }
 
 // buildClientMiddleware builds the client middlewares.
-func (client *Client) buildClientMiddleware() (ms []middleware.Middleware) {
-	ms, _ = chain.BuildMiddleware("http.client", client.opts.cfg.GetMiddlewares())
-	return ms
+func (client *Client) buildClientMiddleware() ([]middleware.Middleware, error) {
+	cfg := client.opts.cfg
+	return chain.BuildMiddleware("http.client", cfg.GetMiddlewares(), chain.Strict(cfg.GetStrictMiddlewares()))
 }
 
 // Invoke makes a rpc call procedure for remote service.
@@ -302,10 +311,7 @@ func (client *Client) Invoke(ctx context.Context, method, path string, args inte
 	if client.opts.userAgent != "" {
 		req.Header.Set("User-Agent", client.opts.userAgent)
 	}
-	app, ok := kratos.FromContext(ctx)
-	if ok {
-		req.Header.Set("x-md-local-caller", app.Name())
-	}
+	caller.Inject(ctx, headerCarrier(req.Header))
 	ctx = transport.NewClientContext(ctx, &Transport{
 		endpoint:     client.opts.endpoint,
 		reqHeader:    headerCarrier(req.Header),
@@ -317,29 +323,52 @@ func (client *Client) Invoke(ctx context.Context, method, path string, args inte
 }
 
 func (client *Client) invoke(ctx context.Context, req *http.Request, args interface{}, reply interface{}, c callInfo, opts ...CallOption) error {
+	var (
+		mu       sync.Mutex
+		newReply atomic.Bool
+	)
 	h := func(ctx context.Context, in interface{}) (interface{}, error) {
-		res, err := client.do(req.WithContext(ctx))
+		// the request may be sent more than once by the middlewares, e.g. retry
+		r := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = body
+		}
+		res, err := client.do(r)
 		if res != nil {
+			mu.Lock()
 			cs := csAttempt{res: res}
 			for _, o := range opts {
 				o.after(&c, &cs)
 			}
+			mu.Unlock()
 		}
 		if err != nil {
 			return nil, err
 		}
 		defer res.Body.Close()
-		if err := client.opts.decoder(ctx, res, reply); err != nil {
+		out := reply
+		if chain.IsNewReply(ctx) {
+			out = chain.NewReply(reply)
+			newReply.Store(true)
+		}
+		if err := client.opts.decoder(ctx, res, out); err != nil {
 			return nil, err
 		}
-		return reply, nil
+		return out, nil
 	}
 	var p selector.Peer
 	ctx = selector.NewPeerContext(ctx, &p)
 	if len(client.opts.middleware) > 0 {
 		h = middleware.Chain(client.opts.middleware...)(h)
 	}
-	_, err := h(ctx, args)
+	out, err := h(ctx, args)
+	if err == nil && newReply.Load() {
+		chain.CopyReply(reply, out)
+	}
 	return err
 }
 
@@ -364,9 +393,13 @@ func (client *Client) do(req *http.Request) (*http.Response, error) {
 			err  error
 			node selector.Node
 		)
-		if node, done, err = client.selector.Select(req.Context(), selector.WithNodeFilter(client.opts.nodeFilters...)); err != nil {
+		if node, done, err = client.selector.Select(req.Context(), selector.WithNodeFilter(nodeselector.Filters(req.Context(), client.opts.nodeFilters)...)); err != nil {
 			return nil, errors.ServiceUnavailable("NODE_NOT_FOUND", err.Error())
 		}
+		if err = nodeselector.Selected(req.Context(), node); err != nil {
+			done(req.Context(), selector.DoneInfo{Err: err})
+			return nil, err
+		}
 		if client.insecure {
 			req.URL.Scheme = "http"
 		} else {
@@ -7,14 +7,19 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	config "github.com/nextmicro/next/api/config/v1"
 	conf "github.com/nextmicro/next/config"
 	"github.com/nextmicro/next/internal/endpoint"
 	"github.com/nextmicro/next/internal/host"
 	"github.com/nextmicro/next/internal/matcher"
 	customMiddleware "github.com/nextmicro/next/middleware"
+	"github.com/nextmicro/next/pkg/caller"
 
+	kconfig "github.com/go-kratos/kratos/v2/config"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/go-kratos/kratos/v2/middleware"
 	"github.com/go-kratos/kratos/v2/transport"
@@ -27,6 +32,11 @@ var (
 	_ http.Handler         = (*Server)(nil)
 )
 
+const (
+	serverKey      = "server.http"
+	middlewaresKey = serverKey + ".middlewares"
+)
+
 // ServerOption is an HTTP server option.
 type ServerOption func(*Server)
 
@@ -167,6 +177,10 @@ type Server struct {
 	timeout     time.Duration
 	filters     []FilterFunc
 	middleware  []middleware.Middleware
+	entries     atomic.Pointer[[]customMiddleware.Entry[middleware.Middleware]]
+	reloadMu    sync.Mutex
+	generation  *customMiddleware.Generation
+	stopped     bool
 	matcher     matcher.Matcher
 	decVars     DecodeRequestFunc
 	decQuery    DecodeRequestFunc
@@ -199,6 +213,8 @@ func NewServer(opts ...ServerOption) *Server {
 	srv.applyConfig()
 	// apply options
 	srv.applyOptions(opts)
+	// build filter options
+	srv.buildFilterChain()
 	// build middleware options
 	srv.buildMiddlewareChain()
 
@@ -232,26 +248,131 @@ func (s *Server) applyOptions(opts []ServerOption) {
 	}
 }
 
-// buildMiddlewareChain builds the middleware chain.
-func (s *Server) buildMiddlewareChain() {
-	serverMiddleware := s.buildServerMiddleware()
-	if len(serverMiddleware) > 0 {
-		userMs := s.middleware
-		s.middleware = append(serverMiddleware, userMs...)
+// buildFilterChain builds the filter chain, the config filters run before the user filters.
+func (s *Server) buildFilterChain() {
+	cfg := conf.ApplicationConfig().GetServer().GetHttp()
+	if cfg == nil {
+		return
 	}
 
-	s.matcher.Use(s.middleware...)
+	fs, err := customMiddleware.BuildFilter("http.server", cfg.GetMiddlewares(), customMiddleware.Strict(cfg.GetStrictMiddlewares()))
+	if err != nil {
+		s.err = err
+		return
+	}
+	if len(fs) == 0 {
+		return
+	}
+	filters := make([]FilterFunc, 0, len(fs)+len(s.filters))
+	for _, f := range fs {
+		filters = append(filters, f)
+	}
+	s.filters = append(filters, s.filters...)
 }
 
-// buildServerMiddleware builds the server middlewares.
-func (s *Server) buildServerMiddleware() (ms []middleware.Middleware) {
+// buildMiddlewareChain builds the middleware chain, the config middlewares run before the user middlewares.
+func (s *Server) buildMiddlewareChain() {
 	cfg := conf.ApplicationConfig().GetServer().GetHttp()
-	if cfg == nil {
-		return ms
+	if err := s.useMiddlewares(cfg.GetMiddlewares(), cfg.GetStrictMiddlewares()); err != nil {
+		s.err = errors.Join(s.err, err)
+		return
 	}
+	s.watchMiddlewares()
+}
 
-	ms, _ = customMiddleware.BuildMiddleware("http.server", cfg.GetMiddlewares())
-	return ms
+// useMiddlewares builds the config middlewares and swaps them into the matcher,
+// the in-flight requests keep running the previous chain.
+// The middlewares with the same options are reused, the replaced ones are closed once the in-flight requests finish.
+func (s *Server) useMiddlewares(ms []*config.Middleware, strict bool) error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	if s.stopped {
+		return nil
+	}
+
+	var prev []customMiddleware.Entry[middleware.Middleware]
+	if p := s.entries.Load(); p != nil {
+		prev = *p
+	}
+	entries, err := customMiddleware.Build("http.server", ms, customMiddleware.Strict(strict), customMiddleware.Reuse(prev))
+	if err != nil {
+		return err
+	}
+
+	g := customMiddleware.NewGeneration()
+	s.entries.Store(&entries)
+	s.matcher.Use(append(append([]middleware.Middleware{g.Middleware()}, customMiddleware.Middlewares(entries)...), s.middleware...)...)
+	if s.generation != nil {
+		s.generation.Retire(func() {
+			closeMiddlewares(prev, entries)
+		})
+	}
+	s.generation = g
+	return nil
+}
+
+// closeMiddlewares closes the middlewares of prev not reused by next.
+func closeMiddlewares(prev, next []customMiddleware.Entry[middleware.Middleware]) {
+	if err := customMiddleware.CloseReplaced(prev, next); err != nil {
+		log.Errorf("[HTTP] close middlewares error: %v", err)
+	}
+}
+
+// closeGeneration closes the config middlewares once the in-flight requests finish, the chain is not reloaded anymore.
+func (s *Server) closeGeneration() {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	s.stopped = true
+	if s.generation == nil {
+		return
+	}
+	var entries []customMiddleware.Entry[middleware.Middleware]
+	if p := s.entries.Load(); p != nil {
+		entries = *p
+	}
+	s.generation.Retire(func() {
+		closeMiddlewares(entries, nil)
+	})
+	s.generation = nil
+}
+
+// watchMiddlewares rebuilds the middleware chain when server.http.middlewares changes,
+// the invalid middlewares are skipped as on startup, or rejected in strict mode keeping the previous chain.
+// The filters are built once, they are not reloaded.
+func (s *Server) watchMiddlewares() {
+	if conf.DefaultConfig == nil {
+		return
+	}
+
+	err := conf.Watch(middlewaresKey, func(_ string, _ kconfig.Value) {
+		s.reloadMiddlewares()
+	})
+	if err != nil && !errors.Is(err, kconfig.ErrNotFound) {
+		log.Errorf("[HTTP] watch [%s] error: %v", middlewaresKey, err)
+	}
+}
+
+// reloadMiddlewares rebuilds the middleware chain from the current config.
+func (s *Server) reloadMiddlewares() {
+	cfg := &config.HTTPServer{}
+	if err := conf.Value(serverKey).Scan(cfg); err != nil {
+		log.Errorf("[HTTP] watch [%s] scan error: %v", middlewaresKey, err)
+		return
+	}
+	if err := s.useMiddlewares(cfg.GetMiddlewares(), cfg.GetStrictMiddlewares()); err != nil {
+		log.Errorf("[HTTP] watch [%s] invalid middlewares, keep the previous chain: %v", middlewaresKey, err)
+		return
+	}
+	log.Infof("[HTTP] watch [%s] reloaded middlewares: %d", middlewaresKey, len(cfg.GetMiddlewares()))
+}
+
+// MiddlewareChain returns the config middlewares running for the operation in order,
+// the user middlewares follow them.
+func (s *Server) MiddlewareChain(operation string) []string {
+	if entries := s.entries.Load(); entries != nil {
+		return customMiddleware.Describe(*entries, operation)
+	}
+	return nil
 }
 
 // Use uses a service middleware with selector.
@@ -356,7 +477,11 @@ func (s *Server) filter() mux.MiddlewareFunc {
 			if s.endpoint != nil {
 				tr.endpoint = s.endpoint.String()
 			}
-			tr.request = req.WithContext(transport.NewServerContext(ctx, tr))
+			ctx = transport.NewServerContext(ctx, tr)
+			if c, ok := caller.Extract(tr.reqHeader); ok {
+				ctx = caller.NewContext(ctx, c)
+			}
+			tr.request = req.WithContext(ctx)
 			next.ServeHTTP(w, tr.request)
 		})
 	}
@@ -398,10 +523,15 @@ func (s *Server) Start(ctx context.Context) error {
 // Stop stop the HTTP server.
 func (s *Server) Stop(ctx context.Context) error {
 	log.Info("[HTTP] server stopping")
-	return s.Shutdown(ctx)
+	err := s.Shutdown(ctx)
+	s.closeGeneration()
+	return err
 }
 
 func (s *Server) listenAndEndpoint() error {
+	if s.err != nil {
+		return s.err
+	}
 	if s.lis == nil {
 		lis, err := net.Listen(s.network, s.address)
 		if err != nil {